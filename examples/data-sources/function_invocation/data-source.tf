@@ -0,0 +1,13 @@
+# The function is invoked on every plan and apply. Inside a check block a
+# failing assertion is reported as a warning and does not block the run.
+check "hello_world_responds" {
+  data "coderforge_function_invocation" "smoke" {
+    function_id = coderforge_function.helloWorldFunction.id
+    payload     = jsonencode({ name = "smoke-test" })
+  }
+
+  assert {
+    condition     = data.coderforge_function_invocation.smoke.status_code == 200
+    error_message = "helloWorld answered ${data.coderforge_function_invocation.smoke.status_code}: ${data.coderforge_function_invocation.smoke.body}"
+  }
+}
@@ -0,0 +1,13 @@
+# Fail the check with the recent logs of the function, so CI output shows
+# why it is unhealthy without opening the console.
+check "hello_world_logs_no_errors" {
+  data "coderforge_function_logs" "recent" {
+    function_id = coderforge_function.helloWorldFunction.id
+    limit       = 50
+  }
+
+  assert {
+    condition     = !anytrue([for line in data.coderforge_function_logs.recent.lines : line.level == "error"])
+    error_message = "helloWorld logged errors:\n${data.coderforge_function_logs.recent.text}"
+  }
+}
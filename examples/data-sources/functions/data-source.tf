@@ -0,0 +1,9 @@
+data "coderforge_functions" "api" {
+  stack_id    = "stack-helloworld-dev"
+  name_prefix = "api-"
+  location    = "gbr-1"
+}
+
+output "api_function_ids" {
+  value = data.coderforge_functions.api.functions[*].id
+}
@@ -0,0 +1,10 @@
+data "coderforge_user" "jane" {
+  email = "jane@example.com"
+}
+
+# Without an email, the user the provider is authenticated as.
+data "coderforge_user" "current" {}
+
+output "current_user_groups" {
+  value = data.coderforge_user.current.groups
+}
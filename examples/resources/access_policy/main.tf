@@ -0,0 +1,26 @@
+resource "coderforge_function" "hello" {
+  function_name = "hello"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/function-hello-world:latest"
+  }
+}
+
+resource "coderforge_domain" "api" {
+  hostname = "api.example.com"
+}
+
+resource "coderforge_api_key" "ci" {
+  name       = "ci"
+  domain_ids = [coderforge_domain.api.id]
+}
+
+# Principals are written "api_key:<id>", "user:<id>" or "group:<name>". User
+# IDs can be looked up by email with the coderforge_user data source.
+resource "coderforge_access_policy" "ci" {
+  name         = "ci"
+  principals   = ["api_key:${coderforge_api_key.ci.id}", "group:developers"]
+  function_ids = [coderforge_function.hello.id]
+  domain_ids   = [coderforge_domain.api.id]
+  actions      = ["invoke", "read_logs"]
+}
@@ -0,0 +1,22 @@
+# Page on-call when helloWorld fails more than 5 times in 5 minutes.
+resource "coderforge_alert" "helloWorldErrors" {
+  name        = "hello-world-errors"
+  function_id = coderforge_function.helloWorldFunction.id
+  metric      = "errors"
+  threshold   = 5
+  notification_channel_ids = [
+    coderforge_notification_channel.oncall.id,
+    coderforge_notification_channel.slack.id,
+  ]
+}
+
+# Warn in Slack when the 95th percentile duration reaches 1.5s over an hour.
+resource "coderforge_alert" "helloWorldSlow" {
+  name                     = "hello-world-slow"
+  function_id              = coderforge_function.helloWorldFunction.id
+  metric                   = "duration_p95"
+  comparison               = "greater_than_or_equal"
+  threshold                = 1500
+  window                   = "1h"
+  notification_channel_ids = [coderforge_notification_channel.slack.id]
+}
@@ -0,0 +1,23 @@
+resource "coderforge_domain" "api" {
+  hostname = "api.example.com"
+}
+
+# The key is only returned when it is created. Increasing rotation, changing
+# expires_at or letting the key expire replaces it with a new one.
+resource "coderforge_api_key" "ci" {
+  name       = "ci"
+  domain_ids = [coderforge_domain.api.id]
+  expires_at = "2027-01-01T00:00:00Z"
+  rotation   = 1
+
+  # Create the new key before the old one is deleted, so clients can switch
+  # over.
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+output "ci_api_key" {
+  value     = coderforge_api_key.ci.key
+  sensitive = true
+}
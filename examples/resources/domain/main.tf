@@ -0,0 +1,30 @@
+# Serve the API on our own hostname with a managed certificate. Publish the
+# validation records in DNS, then wait for the certificate to be issued.
+resource "coderforge_domain" "api" {
+  hostname = "api.example.com"
+}
+
+resource "aws_route53_record" "apiValidation" {
+  zone_id = var.zone_id
+  name    = coderforge_domain.api.validation_records[0].name
+  type    = coderforge_domain.api.validation_records[0].type
+  records = [coderforge_domain.api.validation_records[0].value]
+  ttl     = 300
+}
+
+resource "coderforge_domain_validation" "api" {
+  domain_id = coderforge_domain.api.id
+
+  depends_on = [aws_route53_record.apiValidation]
+
+  timeouts {
+    create = "1h"
+  }
+}
+
+# Serve the website with a certificate we manage ourselves.
+resource "coderforge_domain" "www" {
+  hostname        = "www.example.com"
+  certificate_pem = file("certs/www.example.com.pem")
+  private_key_pem = file("certs/www.example.com.key")
+}
@@ -0,0 +1,27 @@
+# Process order messages ten at a time, retrying failed batches with an
+# exponential backoff before moving them to a dead-letter queue.
+resource "coderforge_event_source" "orders" {
+  name              = "orders"
+  type              = "queue"
+  function_id       = coderforge_function.helloWorldFunction.id
+  queue             = "orders"
+  batch_size        = 10
+  dead_letter_queue = "orders-failed"
+
+  retry_policy = {
+    max_attempts = 3
+    backoff      = "exponential"
+  }
+}
+
+# Receive signed webhooks. Configure the sender with webhook_url and sign
+# requests with webhook_secret.
+resource "coderforge_event_source" "github" {
+  name        = "github"
+  type        = "webhook"
+  function_id = coderforge_function.helloWorldFunction.id
+}
+
+output "github_webhook_url" {
+  value = coderforge_event_source.github.webhook_url
+}
@@ -11,6 +11,10 @@ provider "coderforge" {
   stack_id = "stack-helloworld-dev"
   cloud_space = "helloworld.dev.coderforge.org"
   locations = ["gbr-1", "gbr-2"]
+  request_timeout = "2m"
+  max_requests_per_second = 10
+  read_cache = true
+  bulk_reads = true
 }
 
 resource "coderforge_function" "helloWorldFunction" {
@@ -21,6 +25,32 @@ resource "coderforge_function" "helloWorldFunction" {
   }
   timeout = 180
   max_ram_size = "512MB"
+
+  timeouts {
+    create = "30m"
+    update = "30m"
+  }
+}
+
+# Override how the image starts, and when the platform considers it ready.
+resource "coderforge_function" "api" {
+  function_name = "api"
+  code = {
+    package_type = "container_image"
+    image_uri = "docker.coderforge.org/api:latest"
+  }
+  container = {
+    command = ["/usr/bin/tini", "--"]
+    args = ["api", "--listen", ":8080"]
+    working_dir = "/srv/api"
+    port = 8080
+    health_check = {
+      type = "http"
+      path = "/healthz"
+      interval_seconds = 15
+      unhealthy_threshold = 5
+    }
+  }
 }
 
 output "func1_function" {
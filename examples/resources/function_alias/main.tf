@@ -0,0 +1,23 @@
+resource "coderforge_function" "helloWorldFunction" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+    image_uri = "docker.coderforge.org/function-hello-world:1.4.0"
+  }
+  # Every change publishes a new immutable version.
+  publish = true
+}
+
+# Serve version 3 and send a tenth of the requests to the latest version.
+# Roll back by removing the routing block, or promote by setting
+# function_version to the latest version.
+resource "coderforge_function_alias" "live" {
+  name             = "live"
+  function_id      = coderforge_function.helloWorldFunction.id
+  function_version = 3
+
+  routing = {
+    additional_version = coderforge_function.helloWorldFunction.version
+    weight             = 10
+  }
+}
@@ -0,0 +1,22 @@
+# Forward warnings and errors of helloWorld to an HTTP log collector.
+resource "coderforge_log_drain" "errors" {
+  name     = "errors"
+  protocol = "http"
+  endpoint = "https://logs.example.com/ingest"
+
+  headers = {
+    Authorization = "Bearer ${var.log_collector_token}"
+  }
+
+  filter = {
+    function_ids = [coderforge_function.helloWorldFunction.id]
+    min_level    = "warn"
+  }
+}
+
+# Forward every log line to an OpenTelemetry collector over gRPC.
+resource "coderforge_log_drain" "otel" {
+  name     = "otel"
+  protocol = "otlp"
+  endpoint = "grpcs://otel.example.com:4317"
+}
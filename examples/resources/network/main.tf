@@ -0,0 +1,25 @@
+# The network must span every location the provider deploys functions to.
+resource "coderforge_network" "private" {
+  name       = "private"
+  cidr_block = "10.0.0.0/16"
+  locations  = ["gbr-1", "gbr-2"]
+  subnets = [
+    { name = "gbr-1-db", cidr_block = "10.0.1.0/24", location = "gbr-1" },
+    { name = "gbr-2-db", cidr_block = "10.0.2.0/24", location = "gbr-2" },
+  ]
+}
+
+# The function reaches the databases on the subnets, and cannot open
+# connections to the internet.
+resource "coderforge_function" "orders" {
+  function_name = "orders"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/orders:latest"
+  }
+  network = {
+    network_id = coderforge_network.private.id
+    subnets    = ["gbr-1-db", "gbr-2-db"]
+    egress     = "private_only"
+  }
+}
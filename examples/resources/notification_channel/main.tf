@@ -0,0 +1,13 @@
+# Email the on-call rotation.
+resource "coderforge_notification_channel" "oncall" {
+  name            = "oncall"
+  type            = "email"
+  email_addresses = ["oncall@example.com"]
+}
+
+# Post to a Slack channel through an incoming webhook.
+resource "coderforge_notification_channel" "slack" {
+  name = "alerts-slack"
+  type = "slack"
+  url  = var.slack_webhook_url
+}
@@ -0,0 +1,16 @@
+data "coderforge_user" "jane" {
+  email = "jane@example.com"
+}
+
+# Grant a user a role on the whole cloud space.
+resource "coderforge_role_binding" "jane" {
+  principal = "user:${data.coderforge_user.jane.id}"
+  role      = "viewer"
+}
+
+# Grant a group a role on one stack of the cloud space.
+resource "coderforge_role_binding" "developers" {
+  principal = "group:developers"
+  role      = "deployer"
+  stack_id  = "payments"
+}
@@ -0,0 +1,16 @@
+# Run the nightly report at 02:30 London time on weekdays.
+resource "coderforge_schedule" "nightlyReport" {
+  name        = "nightly-report"
+  cron        = "30 2 * * MON-FRI"
+  timezone    = "Europe/London"
+  function_id = coderforge_function.helloWorldFunction.id
+  payload     = jsonencode({ report = "daily" })
+}
+
+# Warm the cache every 15 minutes. Disabled schedules are kept but not run.
+resource "coderforge_schedule" "cacheWarmer" {
+  name        = "cache-warmer"
+  rate        = "15m"
+  function_id = coderforge_function.helloWorldFunction.id
+  enabled     = false
+}
@@ -0,0 +1,30 @@
+# The value never enters the configuration or the state. It is read from
+# the environment variable (or file) at plan and apply time, and only a
+# salted hash of it is kept in state to detect changes. As it is read while
+# planning, the value cannot come from other resources or data sources.
+resource "coderforge_secret" "dbPassword" {
+  name      = "db-password"
+  value_env = "CODERFORGE_SECRET_DB_PASSWORD"
+
+  # Increase to write the value again, for example after rotating it at its
+  # source without changing it.
+  rotation = 1
+}
+
+resource "coderforge_secret" "apiKey" {
+  name       = "api-key"
+  value_file = "/run/secrets/api-key"
+}
+
+# The function receives the secrets as environment variables.
+resource "coderforge_function" "withSecrets" {
+  function_name = "withSecrets"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/function-hello-world:latest"
+  }
+  secrets = {
+    DB_PASSWORD = coderforge_secret.dbPassword.name
+    API_KEY     = coderforge_secret.apiKey.name
+  }
+}
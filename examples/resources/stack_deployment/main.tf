@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    coderforge = {
+      source = "terraform.coderforge.org/coderforge/coderforge"
+    }
+  }
+  required_version = ">= 0.1.0"
+}
+
+provider "coderforge" {
+  stack_id = "stack-helloworld-dev"
+  cloud_space = "helloworld.dev.coderforge.org"
+  locations = ["gbr-1", "gbr-2"]
+}
+
+resource "coderforge_stack_deployment" "api" {
+  functions = {
+    users = {
+      code = {
+        package_type = "container_image"
+        image_uri = "docker.coderforge.org/api-users:latest"
+      }
+      max_ram_size = "256MB"
+    }
+    orders = {
+      code = {
+        package_type = "container_image"
+        image_uri = "docker.coderforge.org/api-orders:latest"
+      }
+      timeout = 60
+      max_ram_size = "512MB"
+    }
+  }
+}
+
+output "api_function_ids" {
+  value = { for name, function in coderforge_stack_deployment.api.functions : name => function.id }
+}
@@ -0,0 +1,29 @@
+# The volume must be replicated to every location the provider deploys
+# functions to. It grows in place; shrinking it replaces it and loses its
+# data.
+resource "coderforge_volume" "cache" {
+  name      = "cache"
+  size_gb   = 20
+  locations = ["gbr-1", "gbr-2"]
+}
+
+# read_only_many volumes can be mounted by any number of functions, but only
+# with read_only = true.
+resource "coderforge_volume" "models" {
+  name        = "models"
+  size_gb     = 100
+  locations   = ["gbr-1", "gbr-2"]
+  access_mode = "read_only_many"
+}
+
+resource "coderforge_function" "inference" {
+  function_name = "inference"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/inference:latest"
+  }
+  mounts = [
+    { volume_id = coderforge_volume.cache.id, mount_path = "/var/cache/inference" },
+    { volume_id = coderforge_volume.models.id, mount_path = "/models", read_only = true },
+  ]
+}
@@ -0,0 +1,983 @@
+// Package fakeserver implements an in-memory stand-in for the CoderForge.org
+// terraform resource API, so the client and the provider can be tested
+// without network access or a cloud space.
+package fakeserver
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// API paths served by the fake.
+const (
+	ResourcePath   = "/api/1.2/cloud/terraform/resource"
+	InvocationPath = "/api/1.2/cloud/function/invocation"
+	LogsPath       = "/api/1.2/cloud/function/logs"
+	UsersPath      = "/api/1.2/cloud/user"
+)
+
+// Item is a resource item as stored by the fake. Items are kept as decoded
+// JSON objects, so the fake accepts every resource type the provider sends.
+type Item map[string]any
+
+// ID returns the identifier the fake assigned to the item.
+func (i Item) ID() string {
+	id, _ := i["id"].(string)
+	return id
+}
+
+type storedItem struct {
+	seq        int
+	version    int
+	cloudSpace string
+	stackId    string
+	locations  []string
+	item       Item
+
+	// pendingReads counts the reads served while a managed certificate
+	// was pending.
+	pendingReads int
+}
+
+// DefaultPageSize is the number of items per list page when the request
+// does not set pageSize.
+const DefaultPageSize = 100
+
+// Server is a running fake API. Create one with New and stop it with Close.
+type Server struct {
+	*httptest.Server
+
+	// Token, when set, is the only bearer token the fake accepts.
+	Token string
+
+	// PageSize overrides DefaultPageSize, to exercise pagination.
+	PageSize int
+
+	// PendingCertificateReads is the number of reads of a domain that
+	// report its managed certificate pending before it is issued.
+	// Certificates of hostnames under ".invalid" fail validation instead.
+	PendingCertificateReads int
+
+	mu            sync.Mutex
+	nextID        int
+	items         map[string]storedItem
+	request       []string
+	idempotency   map[string]idempotentCreate
+	failCreates   int
+	rejectCreates int
+	pending       []pendingChange
+	invoke        InvokeFunc
+	logs          map[string][]LogLine
+	users         []user
+}
+
+// user is a member of the organization, see AddUser.
+type user struct {
+	ID     string   `json:"id"`
+	Email  string   `json:"email"`
+	Name   string   `json:"name,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// LogLine is a line a function logged.
+type LogLine struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+}
+
+// InvokeFunc answers an invocation of function with a status code and a
+// response body.
+type InvokeFunc func(function Item, payload json.RawMessage) (int, string)
+
+// pendingChange is a field change applied when the next write arrives.
+type pendingChange struct {
+	id    string
+	field string
+	value any
+}
+
+// idempotentCreate is the outcome of a create sent with an Idempotency-Key,
+// replayed when the same key is sent again.
+type idempotentCreate struct {
+	request  string
+	response cloudData
+}
+
+// New starts a fake API server with no resources.
+func New() *Server {
+	s := &Server{items: map[string]storedItem{}, idempotency: map[string]idempotentCreate{}, logs: map[string][]LogLine{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc(ResourcePath, s.handleResource)
+	mux.HandleFunc(InvocationPath, s.handleInvocation)
+	mux.HandleFunc(LogsPath, s.handleLogs)
+	mux.HandleFunc(UsersPath, s.handleUsers)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Resource returns a copy of the stored item with the given ID.
+func (s *Server) Resource(id string) (Item, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.items[id]
+	if !ok {
+		return nil, false
+	}
+	return copyItem(stored.item), true
+}
+
+// Resources returns a copy of every stored item.
+func (s *Server) Resources() []Item {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := make([]Item, 0, len(s.items))
+	for _, stored := range s.items {
+		items = append(items, copyItem(stored.item))
+	}
+	return items
+}
+
+// SetResourceField changes a field of a stored item behind Terraform's back,
+// simulating a change made in the console.
+func (s *Server) SetResourceField(id string, field string, value any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.setField(id, field, value)
+}
+
+// ChangeBeforeNextWrite changes a field of a stored item just before the
+// next update or delete is served, simulating a change made in the console
+// after Terraform read the item.
+func (s *Server) ChangeBeforeNextWrite(id string, field string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, pendingChange{id: id, field: field, value: value})
+}
+
+func (s *Server) setField(id string, field string, value any) error {
+	stored, ok := s.items[id]
+	if !ok {
+		return fmt.Errorf("resource %s not found", id)
+	}
+	stored.item[field] = value
+	s.setVersion(id, stored.version+1)
+	return nil
+}
+
+// setVersion records a new version of a stored item.
+func (s *Server) setVersion(id string, version int) {
+	stored := s.items[id]
+	stored.version = version
+	stored.item["version"] = strconv.Itoa(version)
+	s.items[id] = stored
+}
+
+// RemoveResource deletes a stored item behind Terraform's back.
+func (s *Server) RemoveResource(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, id)
+}
+
+// FailNextCreates makes the next n creates apply but answer with 504 Gateway
+// Timeout, like a proxy that gave up waiting after the API acted.
+func (s *Server) FailNextCreates(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failCreates = n
+}
+
+// RejectNextCreates makes the next n creates answer with 503 Service
+// Unavailable without applying.
+func (s *Server) RejectNextCreates(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rejectCreates = n
+}
+
+// SetInvokeHandler replaces how invoked functions answer. By default they
+// answer 200 OK with their name and the payload they were given.
+func (s *Server) SetInvokeHandler(invoke InvokeFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.invoke = invoke
+}
+
+// Log appends a line to the logs of the function with the given ID. Every
+// invocation logs a line too.
+func (s *Server) Log(functionID string, level string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.log(functionID, level, message)
+}
+
+func (s *Server) log(functionID string, level string, message string) {
+	timestamp := time.Date(2024, 1, 1, 0, 0, len(s.logs[functionID]), 0, time.UTC)
+	s.logs[functionID] = append(s.logs[functionID], LogLine{
+		Timestamp: timestamp.Format(time.RFC3339),
+		Level:     level,
+		Message:   message,
+	})
+}
+
+// Requests returns the method and URL of every request served so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.request...)
+}
+
+// cloudData mirrors the request and response envelope of the API.
+type cloudData struct {
+	StackId       string   `json:"stackId"`
+	CloudSpace    string   `json:"cloudSpace"`
+	Locations     []string `json:"locations"`
+	ResourceItems []Item   `json:"resourceItems"`
+	DataItems     []Item   `json:"dataItems"`
+	NextCursor    string   `json:"nextCursor,omitempty"`
+}
+
+func (s *Server) handleResource(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.request = append(s.request, r.Method+" "+r.URL.RequestURI())
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.get(w, r)
+	case http.MethodPost:
+		s.create(w, r)
+	case http.MethodPut:
+		s.applyPending()
+		s.update(w, r)
+	case http.MethodDelete:
+		s.applyPending()
+		s.delete(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) handleInvocation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.request = append(s.request, r.Method+" "+r.URL.RequestURI())
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id := r.URL.Query().Get("resourceId")
+	stored, ok := s.items[id]
+	if !ok || stored.cloudSpace != r.URL.Query().Get("cloudSpace") || stored.item["type"] != "function" {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("function %s not found", id))
+		return
+	}
+
+	var req struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invoke := s.invoke
+	if invoke == nil {
+		invoke = echo
+	}
+	statusCode, body := invoke(copyItem(stored.item), req.Payload)
+	s.log(id, "info", fmt.Sprintf("invocation finished with status %d", statusCode))
+	writeJSON(w, map[string]any{"statusCode": statusCode, "body": body, "durationMs": 1})
+}
+
+// handleLogs serves the last limit lines a function logged, oldest first.
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.request = append(s.request, r.Method+" "+r.URL.RequestURI())
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id := r.URL.Query().Get("resourceId")
+	stored, ok := s.items[id]
+	if !ok || stored.cloudSpace != r.URL.Query().Get("cloudSpace") || stored.item["type"] != "function" {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("function %s not found", id))
+		return
+	}
+
+	lines := s.logs[id]
+	if limit, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && limit > 0 && limit < len(lines) {
+		lines = lines[len(lines)-limit:]
+	}
+	writeJSON(w, map[string]any{"lines": append([]LogLine{}, lines...)})
+}
+
+// AddUser adds a member to the organization and returns its ID. The first
+// user added is the one the token authenticates.
+func (s *Server) AddUser(email string, name string, groups ...string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := fmt.Sprintf("user-%d", len(s.users)+1)
+	s.users = append(s.users, user{ID: id, Email: email, Name: name, Groups: groups})
+	return id
+}
+
+// handleUsers serves the user with the email address in the query, or the
+// authenticated user.
+func (s *Server) handleUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.request = append(s.request, r.Method+" "+r.URL.RequestURI())
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	email := r.URL.Query().Get("email")
+	for _, u := range s.users {
+		if email == "" || strings.EqualFold(u.Email, email) {
+			writeJSON(w, u)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("user %s not found", email))
+}
+
+// hasUser reports whether a user with the given ID exists.
+func (s *Server) hasUser(id string) bool {
+	for _, u := range s.users {
+		if u.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// echo is the default InvokeFunc.
+func echo(function Item, payload json.RawMessage) (int, string) {
+	if len(payload) == 0 {
+		payload = json.RawMessage("null")
+	}
+	b, _ := json.Marshal(map[string]any{"functionName": function["functionName"], "payload": payload})
+	return http.StatusOK, string(b)
+}
+
+func (s *Server) applyPending() {
+	for _, change := range s.pending {
+		_ = s.setField(change.id, change.field, change.value)
+	}
+	s.pending = nil
+}
+
+// matches reports whether the If-Match header of r, if any, names the
+// current version of the stored item.
+func matches(r *http.Request, stored storedItem) bool {
+	ifMatch := r.Header.Get("If-Match")
+	return ifMatch == "" || ifMatch == "*" || strings.Trim(ifMatch, `"`) == strconv.Itoa(stored.version)
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	if !r.URL.Query().Has("resourceId") {
+		s.list(w, r)
+		return
+	}
+
+	res := cloudData{ResourceItems: []Item{}}
+	for _, id := range r.URL.Query()["resourceId"] {
+		stored, ok := s.items[id]
+		if ok && stored.cloudSpace == r.URL.Query().Get("cloudSpace") {
+			stored = s.validateCertificate(stored)
+			res.ResourceItems = append(res.ResourceItems, copyItem(stored.item))
+		}
+	}
+	writeJSON(w, res)
+}
+
+// validateCertificate moves a pending managed certificate on to issued, or
+// failed, once it was read PendingCertificateReads times.
+func (s *Server) validateCertificate(stored storedItem) storedItem {
+	if stored.item["type"] != "domain" || stored.item["certificateStatus"] != "pending" {
+		return stored
+	}
+	if stored.pendingReads < s.PendingCertificateReads {
+		stored.pendingReads++
+		s.items[stored.item.ID()] = stored
+		return stored
+	}
+	if hostname, _ := stored.item["hostname"].(string); strings.HasSuffix(hostname, ".invalid") {
+		stored.item["certificateStatus"] = "failed"
+	} else {
+		stored.item["certificateStatus"] = "issued"
+	}
+	s.items[stored.item.ID()] = stored
+	return stored
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeCloudData(w, r)
+	if !ok {
+		return
+	}
+	if s.rejectCreates > 0 {
+		s.rejectCreates--
+		writeError(w, http.StatusServiceUnavailable, "service unavailable")
+		return
+	}
+
+	res, replayed, err := s.createItems(req, r.Header.Get("Idempotency-Key"))
+	switch {
+	case err != nil:
+		writeError(w, err.status, err.message)
+	case s.failCreates > 0:
+		s.failCreates--
+		writeError(w, http.StatusGatewayTimeout, "gateway timeout")
+	default:
+		if replayed {
+			w.Header().Set("Idempotent-Replayed", "true")
+		}
+		writeJSON(w, res)
+	}
+}
+
+// createItems stores the items of req. A request repeated with the same
+// idempotency key is answered with the result of the first one, and reported
+// as replayed.
+func (s *Server) createItems(req *cloudData, key string) (cloudData, bool, *apiError) {
+	request := canonicalJSON(req)
+	if previous, ok := s.idempotency[key]; ok && key != "" {
+		if previous.request != request {
+			return cloudData{}, false, &apiError{http.StatusUnprocessableEntity, "Idempotency-Key reused with a different request"}
+		}
+		return previous.response, true, nil
+	}
+	for _, item := range req.ResourceItems {
+		if err := s.validateItem(item, req.Locations); err != nil {
+			return cloudData{}, false, err
+		}
+	}
+
+	res := cloudData{StackId: req.StackId, CloudSpace: req.CloudSpace, Locations: req.Locations}
+	for _, item := range req.ResourceItems {
+		s.nextID++
+		item["id"] = fmt.Sprintf("%v-%d", item["type"], s.nextID)
+		setServerFields(nil, item)
+		s.items[item.ID()] = storedItem{
+			seq:        s.nextID,
+			cloudSpace: req.CloudSpace,
+			stackId:    req.StackId,
+			locations:  req.Locations,
+			item:       item,
+		}
+		s.setVersion(item.ID(), 1)
+		res.ResourceItems = append(res.ResourceItems, copyItem(item))
+		// API keys are only shown once.
+		delete(item, "key")
+	}
+	if key != "" {
+		s.idempotency[key] = idempotentCreate{request: request, response: res}
+	}
+	return res, false, nil
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeCloudData(w, r)
+	if !ok {
+		return
+	}
+
+	// Batches are atomic: check every item before changing any.
+	for _, item := range req.ResourceItems {
+		stored, ok := s.items[item.ID()]
+		if !ok || stored.cloudSpace != req.CloudSpace {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("resource %s not found", item.ID()))
+			return
+		}
+		if !matches(r, stored) {
+			writeError(w, http.StatusPreconditionFailed, fmt.Sprintf("resource %s was changed", item.ID()))
+			return
+		}
+		if err := s.validateItem(item, req.Locations); err != nil {
+			writeError(w, err.status, err.message)
+			return
+		}
+	}
+
+	res := cloudData{StackId: req.StackId, CloudSpace: req.CloudSpace, Locations: req.Locations}
+	for _, item := range req.ResourceItems {
+		stored := s.items[item.ID()]
+		setServerFields(stored.item, item)
+		stored.item = item
+		s.items[item.ID()] = stored
+		s.setVersion(item.ID(), stored.version+1)
+		res.ResourceItems = append(res.ResourceItems, copyItem(item))
+	}
+	writeJSON(w, res)
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeCloudData(w, r)
+	if !ok {
+		return
+	}
+
+	ids := r.URL.Query()["resourceId"]
+	for _, id := range ids {
+		stored, ok := s.items[id]
+		if !ok || stored.cloudSpace != req.CloudSpace {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("resource %s not found", id))
+			return
+		}
+		if !matches(r, stored) {
+			writeError(w, http.StatusPreconditionFailed, fmt.Sprintf("resource %s was changed", id))
+			return
+		}
+	}
+	for _, id := range ids {
+		delete(s.items, id)
+	}
+	writeJSON(w, cloudData{StackId: req.StackId, CloudSpace: req.CloudSpace, Locations: req.Locations})
+}
+
+// list serves the items matching the filters of the query in creation
+// order, one page at a time. The cursor is the offset of the next page.
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var matches []storedItem
+	for _, stored := range s.items {
+		if stored.cloudSpace != query.Get("cloudSpace") {
+			continue
+		}
+		if itemType := query.Get("type"); itemType != "" && stored.item["type"] != itemType {
+			continue
+		}
+		if stackId := query.Get("stackId"); stackId != "" && stored.stackId != stackId {
+			continue
+		}
+		if prefix := query.Get("namePrefix"); prefix != "" && !strings.HasPrefix(itemName(stored.item), prefix) {
+			continue
+		}
+		if location := query.Get("location"); location != "" && !contains(stored.locations, location) {
+			continue
+		}
+		matches = append(matches, stored)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].seq < matches[j].seq })
+
+	pageSize := DefaultPageSize
+	if s.PageSize > 0 {
+		pageSize = s.PageSize
+	}
+	if size, err := strconv.Atoi(query.Get("pageSize")); err == nil && size > 0 {
+		pageSize = size
+	}
+	offset, _ := strconv.Atoi(query.Get("cursor"))
+
+	res := cloudData{CloudSpace: query.Get("cloudSpace"), ResourceItems: []Item{}}
+	for i := offset; i < len(matches) && i < offset+pageSize; i++ {
+		res.ResourceItems = append(res.ResourceItems, copyItem(matches[i].item))
+	}
+	if offset+pageSize < len(matches) {
+		res.NextCursor = strconv.Itoa(offset + pageSize)
+	}
+	writeJSON(w, res)
+}
+
+// apiError is an error the fake answers a request with.
+type apiError struct {
+	status  int
+	message string
+}
+
+// validateItem checks the references an item makes to other items. Locations
+// are the locations of the request the item is written with.
+func (s *Server) validateItem(item Item, locations []string) *apiError {
+	switch item["type"] {
+	case "schedule", "event_source":
+		functionId, _ := item["functionId"].(string)
+		if function, ok := s.items[functionId]; !ok || function.item["type"] != "function" {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("function %s not found", functionId)}
+		}
+	case "function_alias":
+		functionId, _ := item["functionId"].(string)
+		function, ok := s.items[functionId]
+		if !ok {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("function %s not found", functionId)}
+		}
+		published, _ := function.item["publishedVersion"].(float64)
+		for _, field := range []string{"functionVersion", "additionalVersion"} {
+			if version, ok := item[field].(float64); ok && (version < 1 || version > published) {
+				return &apiError{http.StatusBadRequest, fmt.Sprintf("function %s has no published version %v", functionId, version)}
+			}
+		}
+	case "function":
+		secrets, _ := item["secrets"].(map[string]any)
+		for _, secret := range secrets {
+			if !s.hasSecret(secret) {
+				return &apiError{http.StatusBadRequest, fmt.Sprintf("secret %v not found", secret)}
+			}
+		}
+		if network, ok := item["network"].(map[string]any); ok {
+			if err := s.validateFunctionNetwork(network, locations); err != nil {
+				return err
+			}
+		}
+		mounts, _ := item["mounts"].([]any)
+		for _, mount := range mounts {
+			mount, _ := mount.(map[string]any)
+			if err := s.validateMount(item.ID(), mount, locations); err != nil {
+				return err
+			}
+		}
+	case "network":
+		networkLocations := anyStrings(item["locations"])
+		subnets, _ := item["subnets"].([]any)
+		for _, subnet := range subnets {
+			subnet, _ := subnet.(map[string]any)
+			if location, _ := subnet["location"].(string); !contains(networkLocations, location) {
+				return &apiError{http.StatusBadRequest, fmt.Sprintf("subnet %v is in location %s, which the network does not span", subnet["name"], location)}
+			}
+		}
+	case "secret":
+		if value, _ := item["value"].(string); value == "" {
+			return &apiError{http.StatusBadRequest, "a secret needs a value"}
+		}
+	case "access_policy":
+		principals, _ := item["principals"].([]any)
+		for _, principal := range principals {
+			kind, id, _ := strings.Cut(fmt.Sprint(principal), ":")
+			switch kind {
+			case "api_key":
+				if err := s.checkReference(id, "api_key"); err != nil {
+					return err
+				}
+			case "user":
+				if !s.hasUser(id) {
+					return &apiError{http.StatusBadRequest, fmt.Sprintf("user %s not found", id)}
+				}
+			}
+		}
+		for field, itemType := range map[string]string{"functionIds": "function", "domainIds": "domain"} {
+			ids, _ := item[field].([]any)
+			for _, id := range ids {
+				if err := s.checkReference(fmt.Sprint(id), itemType); err != nil {
+					return err
+				}
+			}
+		}
+	case "api_key":
+		ids, _ := item["domainIds"].([]any)
+		for _, id := range ids {
+			if err := s.checkReference(fmt.Sprint(id), "domain"); err != nil {
+				return err
+			}
+		}
+	case "role_binding":
+		if role := item["role"]; role != "viewer" && role != "deployer" && role != "admin" {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("unknown role %v", role)}
+		}
+		kind, id, _ := strings.Cut(fmt.Sprint(item["principal"]), ":")
+		switch {
+		case kind == "user" && !s.hasUser(id):
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("user %s not found", id)}
+		case kind != "user" && kind != "group":
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("invalid principal %v", item["principal"])}
+		}
+	case "alert":
+		functionId, _ := item["functionId"].(string)
+		if function, ok := s.items[functionId]; !ok || function.item["type"] != "function" {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("function %s not found", functionId)}
+		}
+		channelIds, _ := item["channelIds"].([]any)
+		for _, channelId := range channelIds {
+			id, _ := channelId.(string)
+			if channel, ok := s.items[id]; !ok || channel.item["type"] != "notification_channel" {
+				return &apiError{http.StatusBadRequest, fmt.Sprintf("notification channel %s not found", id)}
+			}
+		}
+	case "domain":
+		if item["certificateMode"] == "uploaded" && (item["certificatePem"] == nil || item["privateKeyPem"] == nil) {
+			return &apiError{http.StatusBadRequest, "an uploaded certificate needs certificatePem and privateKeyPem"}
+		}
+	}
+	return nil
+}
+
+// validateFunctionNetwork checks that the network a function is attached to
+// spans the locations of the function, and has the subnets it names in
+// those locations.
+func (s *Server) validateFunctionNetwork(network map[string]any, locations []string) *apiError {
+	if egress := network["egress"]; egress != nil && egress != "all" && egress != "private_only" && egress != "none" {
+		return &apiError{http.StatusBadRequest, fmt.Sprintf("unknown egress mode %v", egress)}
+	}
+	networkId, _ := network["networkId"].(string)
+	if err := s.checkReference(networkId, "network"); err != nil {
+		return err
+	}
+	stored := s.items[networkId].item
+	networkLocations := anyStrings(stored["locations"])
+	for _, location := range locations {
+		if !contains(networkLocations, location) {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("network %s does not span location %s", networkId, location)}
+		}
+	}
+	subnetLocations := map[string]string{}
+	subnets, _ := stored["subnets"].([]any)
+	for _, subnet := range subnets {
+		subnet, _ := subnet.(map[string]any)
+		subnetLocations[fmt.Sprint(subnet["name"])] = fmt.Sprint(subnet["location"])
+	}
+	for _, name := range anyStrings(network["subnets"]) {
+		location, ok := subnetLocations[name]
+		if !ok {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("network %s has no subnet %s", networkId, name)}
+		}
+		if !contains(locations, location) {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("subnet %s is in location %s, where the function does not run", name, location)}
+		}
+	}
+	return nil
+}
+
+// validateMount checks that a function can mount the volume: the volume
+// must be replicated to the locations of the function, read-only volumes
+// can only be mounted read-only, and read-write-once volumes only by one
+// function.
+func (s *Server) validateMount(functionId string, mount map[string]any, locations []string) *apiError {
+	volumeId, _ := mount["volumeId"].(string)
+	if err := s.checkReference(volumeId, "volume"); err != nil {
+		return err
+	}
+	volume := s.items[volumeId].item
+	volumeLocations := anyStrings(volume["locations"])
+	for _, location := range locations {
+		if !contains(volumeLocations, location) {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("volume %s is not replicated to location %s", volumeId, location)}
+		}
+	}
+	switch volume["accessMode"] {
+	case "read_only_many":
+		if mount["readOnly"] != true {
+			return &apiError{http.StatusBadRequest, fmt.Sprintf("volume %s can only be mounted read-only", volumeId)}
+		}
+	case "read_write_once":
+		for id, stored := range s.items {
+			mounts, _ := stored.item["mounts"].([]any)
+			for _, other := range mounts {
+				other, _ := other.(map[string]any)
+				if id != functionId && other["volumeId"] == volumeId {
+					return &apiError{http.StatusConflict, fmt.Sprintf("volume %s is already mounted by function %s", volumeId, id)}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// anyStrings returns the strings in a decoded JSON array.
+func anyStrings(value any) []string {
+	values, _ := value.([]any)
+	strs := make([]string, 0, len(values))
+	for _, v := range values {
+		if str, ok := v.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
+
+// checkReference checks that the item with the given ID exists and has the
+// given type.
+func (s *Server) checkReference(id string, itemType string) *apiError {
+	if stored, ok := s.items[id]; !ok || stored.item["type"] != itemType {
+		return &apiError{http.StatusBadRequest, fmt.Sprintf("%s %s not found", strings.ReplaceAll(itemType, "_", " "), id)}
+	}
+	return nil
+}
+
+// hasSecret reports whether a secret with the given name exists.
+func (s *Server) hasSecret(name any) bool {
+	for _, stored := range s.items {
+		if stored.item["type"] == "secret" && stored.item["name"] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// setServerFields sets the fields the API manages when item is written
+// over previous, which is nil for new items.
+func setServerFields(previous Item, item Item) {
+	switch item["type"] {
+	case "function":
+		// Writing with "publish": true publishes a new immutable version.
+		version, _ := previous["publishedVersion"].(float64)
+		if item["publish"] == true {
+			version++
+		}
+		if version > 0 {
+			item["publishedVersion"] = version
+		} else {
+			delete(item, "publishedVersion")
+		}
+	case "event_source":
+		if item["sourceType"] != "webhook" {
+			delete(item, "webhookUrl")
+			delete(item, "webhookSecret")
+			return
+		}
+		if url, ok := previous["webhookUrl"]; ok {
+			item["webhookUrl"] = url
+			item["webhookSecret"] = previous["webhookSecret"]
+			return
+		}
+		item["webhookUrl"] = "https://hooks.coderforge.org/" + item.ID()
+		secret := make([]byte, 16)
+		_, _ = rand.Read(secret)
+		item["webhookSecret"] = hex.EncodeToString(secret)
+	case "api_key":
+		// The key is only returned by the create request, see createItems.
+		if prefix, ok := previous["keyPrefix"]; ok {
+			item["keyPrefix"] = prefix
+			delete(item, "key")
+			return
+		}
+		key := make([]byte, 24)
+		_, _ = rand.Read(key)
+		item["key"] = "cfk_" + hex.EncodeToString(key)
+		item["keyPrefix"] = item["key"].(string)[:12]
+	case "secret":
+		// The value is write-only, only its hash is returned.
+		value, _ := item["value"].(string)
+		hash := sha256.Sum256([]byte(value))
+		item["valueSha256"] = hex.EncodeToString(hash[:])
+		delete(item, "value")
+	case "log_drain":
+		// The headers are write-only.
+		delete(item, "headers")
+	case "domain":
+		// The private key is write-only.
+		delete(item, "privateKeyPem")
+		if item["certificateMode"] != "managed" {
+			item["certificateStatus"] = "issued"
+			delete(item, "validationRecords")
+			return
+		}
+		delete(item, "certificatePem")
+		if previous["certificateMode"] == "managed" && previous["hostname"] == item["hostname"] {
+			item["certificateStatus"] = previous["certificateStatus"]
+			item["validationRecords"] = previous["validationRecords"]
+			return
+		}
+		token := make([]byte, 16)
+		_, _ = rand.Read(token)
+		item["certificateStatus"] = "pending"
+		item["validationRecords"] = []any{map[string]any{
+			"name":  fmt.Sprintf("_coderforge-challenge.%v.", item["hostname"]),
+			"type":  "CNAME",
+			"value": hex.EncodeToString(token) + ".validation.coderforge.org.",
+		}}
+	}
+}
+
+// itemName returns the name a namePrefix filter matches against.
+func itemName(item Item) string {
+	if name, ok := item["functionName"].(string); ok {
+		return name
+	}
+	name, _ := item["name"].(string)
+	return name
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeCloudData(w http.ResponseWriter, r *http.Request) (*cloudData, bool) {
+	req := cloudData{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return &req, true
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// canonicalJSON encodes v with sorted object keys, so equal requests compare
+// equal.
+func canonicalJSON(v any) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// copyItem returns a deep copy of item, so callers cannot mutate the store.
+func copyItem(item Item) Item {
+	b, _ := json.Marshal(item)
+	c := Item{}
+	_ = json.Unmarshal(b, &c)
+	return c
+}
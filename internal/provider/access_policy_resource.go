@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &accessPolicyResource{}
+	_ resource.ResourceWithConfigure      = &accessPolicyResource{}
+	_ resource.ResourceWithImportState    = &accessPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &accessPolicyResource{}
+)
+
+// principalPattern matches principals such as "api_key:api_key-1" or
+// "user:user-1". Users are named by ID, as in role bindings, so the ID of a
+// coderforge_user data source can be used directly.
+var principalPattern = regexp.MustCompile(`^(api_key|user|group):\S+$`)
+
+// accessPolicyActions are the actions a policy can allow.
+var accessPolicyActions = []string{coderforge.ActionInvoke, coderforge.ActionRead, coderforge.ActionReadLogs}
+
+func NewAccessPolicyResource() resource.Resource {
+	return &accessPolicyResource{}
+}
+
+type accessPolicyResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Principals  types.List   `tfsdk:"principals"`
+	FunctionIds types.List   `tfsdk:"function_ids"`
+	DomainIds   types.List   `tfsdk:"domain_ids"`
+	Actions     types.List   `tfsdk:"actions"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+type accessPolicyResource struct {
+	client *coderforge.Client
+}
+
+func (r *accessPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_policy"
+}
+
+func (r *accessPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"principals": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"function_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			// domain_ids allows the actions on every endpoint of the domains.
+			"domain_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"actions": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the principals, targets and actions at plan time.
+func (r *accessPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config accessPolicyResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Principals.IsUnknown() && len(config.Principals.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("principals"),
+			"Missing Principal",
+			"An access policy must name at least one principal.",
+		)
+	}
+	for i, principal := range config.Principals.Elements() {
+		principal, ok := principal.(types.String)
+		if ok && knownString(principal) && !principalPattern.MatchString(principal.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("principals").AtListIndex(i),
+				"Invalid Principal",
+				"Principals must be written \"api_key:<id>\", \"user:<id>\" or \"group:<name>\", got: "+principal.ValueString(),
+			)
+		}
+	}
+
+	if config.FunctionIds.IsNull() && config.DomainIds.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("function_ids"),
+			"Missing Access Policy Target",
+			"At least one of function_ids or domain_ids must be set.",
+		)
+	}
+
+	if !config.Actions.IsUnknown() && len(config.Actions.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("actions"),
+			"Missing Action",
+			"An access policy must allow at least one action.",
+		)
+	}
+	seen := map[string]bool{}
+	for i, action := range config.Actions.Elements() {
+		action, ok := action.(types.String)
+		if !ok || !knownString(action) {
+			continue
+		}
+		if !slices.Contains(accessPolicyActions, action.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("actions").AtListIndex(i),
+				"Invalid Action",
+				"The actions must be invoke, read or read_logs, got: "+action.ValueString(),
+			)
+		}
+		if seen[action.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("actions").AtListIndex(i),
+				"Duplicate Action",
+				"The action "+action.ValueString()+" is listed more than once.",
+			)
+		}
+		seen[action.ValueString()] = true
+	}
+}
+
+func (r *accessPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan accessPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := accessPolicyItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	policy, err := r.client.AccessPolicies().Create(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating access policy",
+			"Could not create access policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newAccessPolicyModel(ctx, policy)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *accessPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state accessPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.AccessPolicies().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Access Policy",
+			"Could not read access policy ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The policy was deleted outside Terraform, plan to create it again.
+	if policy == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state, diags = newAccessPolicyModel(ctx, policy)
+	resp.Diagnostics.Append(diags...)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *accessPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan accessPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state accessPolicyResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := accessPolicyItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	item.ID = state.ID.ValueString()
+	policy, err := r.client.AccessPolicies().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating access policy",
+			"Could not update access policy, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newAccessPolicyModel(ctx, policy)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *accessPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state accessPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.AccessPolicies().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting access policy",
+			"Could not delete access policy, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *accessPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *accessPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func accessPolicyItem(ctx context.Context, plan accessPolicyResourceModel) (coderforge.AccessPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	policy := coderforge.AccessPolicy{
+		Name: plan.Name.ValueString(),
+	}
+	diags.Append(plan.Principals.ElementsAs(ctx, &policy.Principals, false)...)
+	diags.Append(plan.Actions.ElementsAs(ctx, &policy.Actions, false)...)
+	if !plan.FunctionIds.IsNull() {
+		diags.Append(plan.FunctionIds.ElementsAs(ctx, &policy.FunctionIds, false)...)
+	}
+	if !plan.DomainIds.IsNull() {
+		diags.Append(plan.DomainIds.ElementsAs(ctx, &policy.DomainIds, false)...)
+	}
+	return policy, diags
+}
+
+func newAccessPolicyModel(ctx context.Context, policy *coderforge.AccessPolicy) (accessPolicyResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	principals, d := types.ListValueFrom(ctx, types.StringType, policy.Principals)
+	diags.Append(d...)
+	actions, d := types.ListValueFrom(ctx, types.StringType, policy.Actions)
+	diags.Append(d...)
+	return accessPolicyResourceModel{
+		ID:          types.StringValue(policy.ID),
+		Name:        types.StringValue(policy.Name),
+		Principals:  principals,
+		FunctionIds: stringListValueOrNull(policy.FunctionIds),
+		DomainIds:   stringListValueOrNull(policy.DomainIds),
+		Actions:     actions,
+	}, diags
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAccessPolicyResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	config := func(actions string) string {
+		return testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 30) + fmt.Sprintf(`
+resource "coderforge_domain" "api" {
+  hostname = "api.example.com"
+}
+
+resource "coderforge_api_key" "ci" {
+  name       = "ci"
+  domain_ids = [coderforge_domain.api.id]
+}
+
+resource "coderforge_access_policy" "ci" {
+  name         = "ci"
+  principals   = ["api_key:${coderforge_api_key.ci.id}", "group:developers"]
+  function_ids = [coderforge_function.test.id]
+  domain_ids   = [coderforge_domain.api.id]
+  actions      = %s
+}
+`, actions)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "domain", "api_key", "access_policy"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(`["invoke"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_access_policy.ci", "id"),
+					resource.TestCheckResourceAttrPair("coderforge_access_policy.ci", "function_ids.0", "coderforge_function.test", "id"),
+					resource.TestCheckResourceAttrPair("coderforge_access_policy.ci", "domain_ids.0", "coderforge_domain.api", "id"),
+					resource.TestCheckResourceAttr("coderforge_access_policy.ci", "principals.1", "group:developers"),
+					resource.TestCheckResourceAttr("coderforge_access_policy.ci", "actions.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_access_policy.ci",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: config(`["invoke", "read", "read_logs"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_access_policy.ci", "actions.#", "3"),
+					resource.TestCheckResourceAttr("coderforge_access_policy.ci", "actions.2", "read_logs"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAccessPolicyResource_userPrincipal(t *testing.T) {
+	server := testAccFakeServer(t)
+	janeID := server.AddUser("jane@example.com", "Jane Doe")
+
+	config := func(principal string) string {
+		return testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 30) + fmt.Sprintf(`
+data "coderforge_user" "jane" {
+  email = "jane@example.com"
+}
+
+resource "coderforge_access_policy" "jane" {
+  name         = "jane"
+  principals   = [%s]
+  function_ids = [coderforge_function.test.id]
+  actions      = ["invoke"]
+}
+`, principal)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "access_policy"),
+		Steps: []resource.TestStep{
+			// Users are named by ID...
+			{
+				Config: config(`"user:${data.coderforge_user.jane.id}"`),
+				Check:  resource.TestCheckResourceAttr("coderforge_access_policy.jane", "principals.0", "user:"+janeID),
+			},
+			// ...not by email.
+			{
+				Config:      config(`"user:jane@example.com"`),
+				ExpectError: regexp.MustCompile(`user jane@example.com not found`),
+			},
+		},
+	})
+}
+
+func TestAccAccessPolicyResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		policy string
+		err    string
+	}{
+		"bad principal":    {"principals = [\"jane\"]\n  function_ids = [\"f\"]\n  actions = [\"invoke\"]", `Invalid Principal`},
+		"no principals":    {"principals = []\n  function_ids = [\"f\"]\n  actions = [\"invoke\"]", `Missing Principal`},
+		"no target":        {"principals = [\"user:jane\"]\n  actions = [\"invoke\"]", `Missing Access Policy Target`},
+		"bad action":       {"principals = [\"user:jane\"]\n  function_ids = [\"f\"]\n  actions = [\"write\"]", `Invalid Action`},
+		"duplicate action": {"principals = [\"user:jane\"]\n  function_ids = [\"f\"]\n  actions = [\"read\", \"read\"]", `Duplicate Action`},
+		"no actions":       {"principals = [\"user:jane\"]\n  function_ids = [\"f\"]\n  actions = []", `Missing Action`},
+		"unknown function": {"principals = [\"group:developers\"]\n  function_ids = [\"f\"]\n  actions = [\"invoke\"]", `function f not found`},
+		"unknown api key":  {"principals = [\"api_key:k\"]\n  domain_ids = [\"d\"]\n  actions = [\"invoke\"]", `not found`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_access_policy" "test" {
+  name = "test"
+  ` + tc.policy + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &alertResource{}
+	_ resource.ResourceWithConfigure      = &alertResource{}
+	_ resource.ResourceWithImportState    = &alertResource{}
+	_ resource.ResourceWithValidateConfig = &alertResource{}
+)
+
+// alertMetrics are the metrics an alert can watch.
+var alertMetrics = []string{
+	coderforge.MetricErrors,
+	coderforge.MetricThrottles,
+	coderforge.MetricInvocations,
+	coderforge.MetricDurationP50,
+	coderforge.MetricDurationP95,
+	coderforge.MetricDurationP99,
+}
+
+// alertComparisons are the ways a metric can cross its threshold.
+var alertComparisons = []string{"greater_than", "greater_than_or_equal", "less_than", "less_than_or_equal"}
+
+// maxAlertWindow is the longest window a metric is aggregated over.
+const maxAlertWindow = 24 * time.Hour
+
+func NewAlertResource() resource.Resource {
+	return &alertResource{}
+}
+
+type alertResourceModel struct {
+	ID                     types.String  `tfsdk:"id"`
+	Name                   types.String  `tfsdk:"name"`
+	FunctionId             types.String  `tfsdk:"function_id"`
+	Metric                 types.String  `tfsdk:"metric"`
+	Comparison             types.String  `tfsdk:"comparison"`
+	Threshold              types.Float64 `tfsdk:"threshold"`
+	Window                 types.String  `tfsdk:"window"`
+	NotificationChannelIds types.List    `tfsdk:"notification_channel_ids"`
+	Enabled                types.Bool    `tfsdk:"enabled"`
+	LastUpdated            types.String  `tfsdk:"last_updated"`
+}
+
+type alertResource struct {
+	client *coderforge.Client
+}
+
+func (r *alertResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert"
+}
+
+func (r *alertResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"function_id": schema.StringAttribute{
+				Required: true,
+			},
+			"metric": schema.StringAttribute{
+				Required: true,
+			},
+			"comparison": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("greater_than"),
+			},
+			// Durations are measured in milliseconds.
+			"threshold": schema.Float64Attribute{
+				Required: true,
+			},
+			"window": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("5m"),
+			},
+			"notification_channel_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the metric, threshold, window and channels at plan
+// time.
+func (r *alertResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config alertResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if knownString(config.Metric) && !slices.Contains(alertMetrics, config.Metric.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("metric"),
+			"Invalid Alert Metric",
+			"The metric must be one of errors, throttles, invocations, duration_p50, duration_p95 or duration_p99, got: "+config.Metric.ValueString(),
+		)
+	}
+	if knownString(config.Comparison) && !slices.Contains(alertComparisons, config.Comparison.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("comparison"),
+			"Invalid Alert Comparison",
+			"The comparison must be one of greater_than, greater_than_or_equal, less_than or less_than_or_equal, got: "+config.Comparison.ValueString(),
+		)
+	}
+	if threshold := config.Threshold; !threshold.IsNull() && !threshold.IsUnknown() && threshold.ValueFloat64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("threshold"),
+			"Invalid Alert Threshold",
+			fmt.Sprintf("Metrics are never negative, so the threshold must be at least 0, got: %g.", threshold.ValueFloat64()),
+		)
+	}
+	if knownString(config.Window) {
+		window, err := time.ParseDuration(config.Window.ValueString())
+		if err != nil || window < time.Minute || window > maxAlertWindow || window%time.Minute != 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("window"),
+				"Invalid Alert Window",
+				"The window must be a whole number of minutes between \"1m\" and \"24h\", got: "+config.Window.ValueString(),
+			)
+		}
+	}
+	if channels := config.NotificationChannelIds; !channels.IsNull() && !channels.IsUnknown() && len(channels.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("notification_channel_ids"),
+			"Missing Notification Channel",
+			"An alert must notify at least one channel.",
+		)
+	}
+}
+
+func (r *alertResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan alertResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := alertItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	alert, err := r.client.Alerts().Create(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating alert",
+			"Could not create alert, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newAlertModel(ctx, alert)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *alertResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state alertResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alert, err := r.client.Alerts().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Alert",
+			"Could not read alert ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The alert was deleted outside Terraform, plan to create it again.
+	if alert == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state, diags = newAlertModel(ctx, alert)
+	resp.Diagnostics.Append(diags...)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *alertResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan alertResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state alertResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := alertItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	item.ID = state.ID.ValueString()
+	alert, err := r.client.Alerts().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating alert",
+			"Could not update alert, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newAlertModel(ctx, alert)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *alertResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state alertResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Alerts().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting alert",
+			"Could not delete alert, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *alertResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *alertResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func alertItem(ctx context.Context, plan alertResourceModel) (coderforge.Alert, diag.Diagnostics) {
+	alert := coderforge.Alert{
+		Name:       plan.Name.ValueString(),
+		FunctionId: plan.FunctionId.ValueString(),
+		Metric:     plan.Metric.ValueString(),
+		Comparison: plan.Comparison.ValueString(),
+		Threshold:  plan.Threshold.ValueFloat64(),
+		Window:     plan.Window.ValueString(),
+		Enabled:    plan.Enabled.ValueBool(),
+	}
+	diags := plan.NotificationChannelIds.ElementsAs(ctx, &alert.ChannelIds, false)
+	return alert, diags
+}
+
+func newAlertModel(ctx context.Context, alert *coderforge.Alert) (alertResourceModel, diag.Diagnostics) {
+	channelIds, diags := types.ListValueFrom(ctx, types.StringType, alert.ChannelIds)
+	return alertResourceModel{
+		ID:                     types.StringValue(alert.ID),
+		Name:                   types.StringValue(alert.Name),
+		FunctionId:             types.StringValue(alert.FunctionId),
+		Metric:                 types.StringValue(alert.Metric),
+		Comparison:             types.StringValue(alert.Comparison),
+		Threshold:              types.Float64Value(alert.Threshold),
+		Window:                 types.StringValue(alert.Window),
+		NotificationChannelIds: channelIds,
+		Enabled:                types.BoolValue(alert.Enabled),
+	}, diags
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccNotificationChannelConfig = `
+resource "coderforge_notification_channel" "oncall" {
+  name            = "oncall"
+  type            = "email"
+  email_addresses = ["oncall@example.com"]
+}
+`
+
+func TestAccAlertResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "notification_channel", "alert"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + testAccNotificationChannelConfig + `
+resource "coderforge_alert" "errors" {
+  name                     = "hello-errors"
+  function_id              = coderforge_function.test.id
+  metric                   = "errors"
+  threshold                = 5
+  notification_channel_ids = [coderforge_notification_channel.oncall.id]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_alert.errors", "id"),
+					resource.TestCheckResourceAttr("coderforge_alert.errors", "comparison", "greater_than"),
+					resource.TestCheckResourceAttr("coderforge_alert.errors", "window", "5m"),
+					resource.TestCheckResourceAttr("coderforge_alert.errors", "enabled", "true"),
+					resource.TestCheckResourceAttrPair("coderforge_alert.errors", "notification_channel_ids.0", "coderforge_notification_channel.oncall", "id"),
+					testAccCheckItemField(server, "coderforge_alert.errors", "type", "alert"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_alert.errors",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Watch the p95 duration over a longer window instead.
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + testAccNotificationChannelConfig + `
+resource "coderforge_alert" "errors" {
+  name                     = "hello-errors"
+  function_id              = coderforge_function.test.id
+  metric                   = "duration_p95"
+  comparison               = "greater_than_or_equal"
+  threshold                = 1500.5
+  window                   = "1h"
+  notification_channel_ids = [coderforge_notification_channel.oncall.id]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_alert.errors", "metric", "duration_p95"),
+					resource.TestCheckResourceAttr("coderforge_alert.errors", "threshold", "1500.5"),
+					testAccCheckItemField(server, "coderforge_alert.errors", "window", "1h"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlertResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		alert string
+		err   string
+	}{
+		"unknown metric":     {"metric = \"cpu\"\n  threshold = 1", `Invalid Alert Metric`},
+		"unknown comparison": {"metric = \"errors\"\n  threshold = 1\n  comparison = \"above\"", `Invalid Alert Comparison`},
+		"negative threshold": {"metric = \"errors\"\n  threshold = -1", `Invalid Alert Threshold`},
+		"window in seconds":  {"metric = \"errors\"\n  threshold = 1\n  window = \"90s\"", `Invalid Alert Window`},
+		"window too long":    {"metric = \"errors\"\n  threshold = 1\n  window = \"48h\"", `Invalid Alert Window`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_alert" "test" {
+  name                     = "test"
+  function_id              = "function-1"
+  notification_channel_ids = ["notification_channel-2"]
+  ` + tc.alert + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+
+	t.Run("no channels", func(t *testing.T) {
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccProviderConfig(server) + `
+resource "coderforge_alert" "test" {
+  name                     = "test"
+  function_id              = "function-1"
+  metric                   = "throttles"
+  threshold                = 0
+  notification_channel_ids = []
+}
+`,
+					ExpectError: regexp.MustCompile(`Missing Notification Channel`),
+				},
+			},
+		})
+	})
+}
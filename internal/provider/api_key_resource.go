@@ -0,0 +1,353 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &apiKeyResource{}
+	_ resource.ResourceWithConfigure      = &apiKeyResource{}
+	_ resource.ResourceWithImportState    = &apiKeyResource{}
+	_ resource.ResourceWithModifyPlan     = &apiKeyResource{}
+	_ resource.ResourceWithValidateConfig = &apiKeyResource{}
+)
+
+func NewAPIKeyResource() resource.Resource {
+	return &apiKeyResource{}
+}
+
+type apiKeyResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	DomainIds   types.List   `tfsdk:"domain_ids"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+	Rotation    types.Int64  `tfsdk:"rotation"`
+	Key         types.String `tfsdk:"key"`
+	KeyPrefix   types.String `tfsdk:"key_prefix"`
+	Expired     types.Bool   `tfsdk:"expired"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+type apiKeyResource struct {
+	client *coderforge.Client
+}
+
+func (r *apiKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+func (r *apiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			// expires_at is an RFC 3339 timestamp. Once it has passed, the
+			// next plan replaces the key.
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// Increasing rotation replaces the key with a new one.
+			"rotation": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			// key is only returned when the key is created, and is kept in
+			// state from then on.
+			"key": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_prefix": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			// expired is set when the key is read after expires_at. The next
+			// plan replaces it.
+			"expired": schema.BoolAttribute{
+				Computed: true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the expiry timestamp and the rotation counter.
+func (r *apiKeyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config apiKeyResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if knownString(config.ExpiresAt) {
+		if _, err := time.Parse(time.RFC3339, config.ExpiresAt.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expires_at"),
+				"Invalid Expiry",
+				"The expires_at must be an RFC 3339 timestamp such as 2030-01-01T00:00:00Z, got: "+config.ExpiresAt.ValueString(),
+			)
+		}
+	}
+	if !config.Rotation.IsNull() && !config.Rotation.IsUnknown() && config.Rotation.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotation"),
+			"Invalid API Key Rotation",
+			"The rotation counter must be at least 0.",
+		)
+	}
+}
+
+// ModifyPlan replaces keys once they have expired, and refuses to create
+// keys that are already expired, so an expired key is only replaced after
+// expires_at has been moved forward.
+func (r *apiKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan apiKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || !knownString(plan.ExpiresAt) {
+		return
+	}
+	if !apiKeyExpired(plan.ExpiresAt.ValueString()) {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expires_at"),
+			"API Key Expired",
+			"The expires_at has passed, set a later expiry to create a new key. Got: "+plan.ExpiresAt.ValueString(),
+		)
+		return
+	}
+
+	// The key has expired, plan a new one. Terraform only replaces
+	// resources for attributes whose value changes, hence expired. The new
+	// key is planned again without state, which reports the expiry above.
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expired"))
+	diags = resp.Plan.SetAttribute(ctx, path.Root("expired"), types.BoolValue(false))
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *apiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan apiKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := apiKeyItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	apiKey, err := r.client.APIKeys().Create(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating API key",
+			"Could not create API key, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newAPIKeyModel(apiKey)
+	plan.Key = types.StringValue(apiKey.Key)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *apiKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state apiKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiKey, err := r.client.APIKeys().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading API Key",
+			"Could not read API key ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The key was deleted outside Terraform, plan to create it again.
+	if apiKey == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	key := state.Key
+	lastUpdated := state.LastUpdated
+	state = newAPIKeyModel(apiKey)
+	state.Key = key
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes the domains the key is attached to; every other
+// change replaces the key.
+func (r *apiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan apiKeyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state apiKeyResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := apiKeyItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	item.ID = state.ID.ValueString()
+	apiKey, err := r.client.APIKeys().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating API key",
+			"Could not update API key, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newAPIKeyModel(apiKey)
+	plan.Key = state.Key
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *apiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state apiKeyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.APIKeys().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting API key",
+			"Could not delete API key, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports the key without its value, which the API no longer
+// returns.
+func (r *apiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *apiKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func apiKeyItem(ctx context.Context, plan apiKeyResourceModel) (coderforge.APIKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	apiKey := coderforge.APIKey{
+		Name:      plan.Name.ValueString(),
+		ExpiresAt: plan.ExpiresAt.ValueString(),
+		Rotation:  plan.Rotation.ValueInt64(),
+	}
+	if !plan.DomainIds.IsNull() {
+		diags.Append(plan.DomainIds.ElementsAs(ctx, &apiKey.DomainIds, false)...)
+	}
+	return apiKey, diags
+}
+
+func newAPIKeyModel(apiKey *coderforge.APIKey) apiKeyResourceModel {
+	return apiKeyResourceModel{
+		ID:        types.StringValue(apiKey.ID),
+		Name:      types.StringValue(apiKey.Name),
+		DomainIds: stringListValueOrNull(apiKey.DomainIds),
+		ExpiresAt: stringValueOrNull(apiKey.ExpiresAt),
+		Rotation:  types.Int64Value(apiKey.Rotation),
+		Key:       types.StringNull(),
+		KeyPrefix: types.StringValue(apiKey.KeyPrefix),
+		Expired:   types.BoolValue(apiKeyExpired(apiKey.ExpiresAt)),
+	}
+}
+
+// apiKeyExpired reports whether the RFC 3339 timestamp expiresAt has passed.
+// Keys without an expiry never expire.
+func apiKeyExpired(expiresAt string) bool {
+	t, err := time.Parse(time.RFC3339, expiresAt)
+	return err == nil && !t.After(time.Now())
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAPIKeyResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var keyID, key string
+	config := func(rotation int, expiresAt string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_domain" "api" {
+  hostname = "api.example.com"
+}
+
+resource "coderforge_api_key" "ci" {
+  name       = "ci"
+  domain_ids = [coderforge_domain.api.id]
+  expires_at = %q
+  rotation   = %d
+}
+`, expiresAt, rotation)
+	}
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "domain", "api_key"),
+		Steps: []resource.TestStep{
+			// The key is returned once and kept in state.
+			{
+				Config: config(0, expiresAt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_api_key.ci", "id"),
+					resource.TestMatchResourceAttr("coderforge_api_key.ci", "key", regexp.MustCompile(`^cfk_[0-9a-f]{48}$`)),
+					resource.TestMatchResourceAttr("coderforge_api_key.ci", "key_prefix", regexp.MustCompile(`^cfk_[0-9a-f]{8}$`)),
+					testAccCheckItemField(server, "coderforge_api_key.ci", "key", nil),
+					testAccCaptureID("coderforge_api_key.ci", &keyID),
+					testAccCaptureAttr("coderforge_api_key.ci", "key", &key),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_api_key.ci",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key", "last_updated"},
+			},
+			// Increasing the rotation counter replaces the key.
+			{
+				Config: config(1, expiresAt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAttributeChanged("coderforge_api_key.ci", "id", &keyID),
+					testAccCheckAttributeChanged("coderforge_api_key.ci", "key", &key),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIKeyResource_expiry(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	config := func(expiresAt time.Time) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_api_key" "ci" {
+  name       = "ci"
+  expires_at = %q
+}
+`, expiresAt.UTC().Format(time.RFC3339))
+	}
+	expiresAt := time.Now().Add(3 * time.Second)
+
+	var keyID string
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "api_key"),
+		Steps: []resource.TestStep{
+			{
+				Config: config(expiresAt),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_api_key.ci", "expired", "false"),
+					testAccCaptureID("coderforge_api_key.ci", &keyID),
+				),
+			},
+			// Once the key has expired, it is only replaced with a later
+			// expiry.
+			{
+				PreConfig:   func() { time.Sleep(time.Until(expiresAt) + time.Second) },
+				Config:      config(expiresAt),
+				ExpectError: regexp.MustCompile(`API Key Expired`),
+			},
+			{
+				Config: config(time.Now().Add(time.Hour)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_api_key.ci", "expired", "false"),
+					testAccCheckAttributeChanged("coderforge_api_key.ci", "id", &keyID),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIKeyResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		apiKey string
+		err    string
+	}{
+		"bad expiry":     {`expires_at = "tomorrow"`, `Invalid Expiry`},
+		"past expiry":    {`expires_at = "2020-01-01T00:00:00Z"`, `API Key Expired`},
+		"negative count": {`rotation = -1`, `Invalid API Key Rotation`},
+		"unknown domain": {`domain_ids = ["d"]`, `not found`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_api_key" "test" {
+  name = "test"
+  ` + tc.apiKey + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+// testAccCheckAttributeChanged checks that an attribute of the named
+// resource differs from the captured value.
+func testAccCheckAttributeChanged(name string, attribute string, previous *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		if value := rs.Primary.Attributes[attribute]; value == *previous {
+			return fmt.Errorf("%s.%s is still %q", name, attribute, value)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cronField describes one field of a cron expression.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+// cronFields are the fields of a standard five-field cron expression.
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	// 0 and 7 are both Sunday.
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// cronMacros are the shorthands accepted in place of five fields.
+var cronMacros = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// validateCron checks a five-field cron expression such as "30 2 * * MON-FRI".
+// Each field is a comma separated list of "*", values, ranges "a-b" and steps
+// "*/n" or "a-b/n".
+func validateCron(expression string) error {
+	expression = strings.TrimSpace(expression)
+	if strings.HasPrefix(expression, "@") {
+		if !cronMacros[expression] {
+			return fmt.Errorf("unknown macro %q", expression)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields (minute, hour, day of month, month, day of week), got %d", len(cronFields), len(fields))
+	}
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return fmt.Errorf("%s field %q: %w", cronFields[i].name, field, err)
+		}
+	}
+	return nil
+}
+
+func (f cronField) validate(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		if err := f.validateItem(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f cronField) validateItem(item string) error {
+	rangePart, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n < 1 {
+			return fmt.Errorf("step %q must be a positive number", step)
+		}
+	}
+
+	if rangePart == "*" {
+		return nil
+	}
+	low, high, isRange := strings.Cut(rangePart, "-")
+	first, err := f.value(low)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+	last, err := f.value(high)
+	if err != nil {
+		return err
+	}
+	if first > last {
+		return fmt.Errorf("range %s is backwards", rangePart)
+	}
+	return nil
+}
+
+// value parses a number or, for months and days of the week, a name.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return i + f.min, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", n, f.min, f.max)
+	}
+	return n, nil
+}
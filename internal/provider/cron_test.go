@@ -0,0 +1,43 @@
+package provider
+
+import "testing"
+
+func TestValidateCron(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"30 2 * * *",
+		"*/15 * * * *",
+		"0 9-17/2 * * MON-FRI",
+		"0 0 1,15 * *",
+		"0 0 1 jan,jul *",
+		"0 0 * * 0",
+		"0 0 * * 7",
+		"@daily",
+	}
+	for _, expression := range valid {
+		if err := validateCron(expression); err != nil {
+			t.Errorf("expected %q to be valid, got: %s", expression, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * FOO *",
+		"a * * * *",
+		"@sometimes",
+	}
+	for _, expression := range invalid {
+		if err := validateCron(expression); err == nil {
+			t.Errorf("expected %q to be invalid", expression)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &domainResource{}
+	_ resource.ResourceWithConfigure      = &domainResource{}
+	_ resource.ResourceWithImportState    = &domainResource{}
+	_ resource.ResourceWithValidateConfig = &domainResource{}
+)
+
+// hostnamePattern matches lower case DNS names of at least two labels,
+// optionally with a leading wildcard label.
+var hostnamePattern = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z][a-z0-9-]{0,61}[a-z0-9]$`)
+
+// dnsRecordType is the object type of a validation record.
+var dnsRecordType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":  types.StringType,
+	"type":  types.StringType,
+	"value": types.StringType,
+}}
+
+func NewDomainResource() resource.Resource {
+	return &domainResource{}
+}
+
+type domainResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Hostname          types.String `tfsdk:"hostname"`
+	CertificatePem    types.String `tfsdk:"certificate_pem"`
+	PrivateKeyPem     types.String `tfsdk:"private_key_pem"`
+	CertificateStatus types.String `tfsdk:"certificate_status"`
+	ValidationRecords types.List   `tfsdk:"validation_records"`
+	LastUpdated       types.String `tfsdk:"last_updated"`
+}
+
+type domainResource struct {
+	client *coderforge.Client
+}
+
+func (r *domainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (r *domainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// Without an uploaded certificate the API requests a managed
+			// one, issued once the validation records are in DNS.
+			"certificate_pem": schema.StringAttribute{
+				Optional: true,
+			},
+			"private_key_pem": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"certificate_status": schema.StringAttribute{
+				Computed: true,
+			},
+			"validation_records": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the hostname, and that an uploaded certificate
+// matches its key and covers the hostname.
+func (r *domainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config domainResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if knownString(config.Hostname) && !hostnamePattern.MatchString(config.Hostname.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"Invalid Hostname",
+			"The hostname must be a lower case DNS name such as \"api.example.com\" or \"*.example.com\", got: "+config.Hostname.ValueString(),
+		)
+		return
+	}
+
+	if config.CertificatePem.IsNull() != config.PrivateKeyPem.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate_pem"),
+			"Incomplete Certificate",
+			"Set both certificate_pem and private_key_pem to upload a certificate, or neither to use a managed certificate.",
+		)
+		return
+	}
+	if !knownString(config.CertificatePem) || !knownString(config.PrivateKeyPem) {
+		return
+	}
+
+	pair, err := tls.X509KeyPair([]byte(config.CertificatePem.ValueString()), []byte(config.PrivateKeyPem.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate_pem"),
+			"Invalid Certificate",
+			"The certificate_pem and private_key_pem must be a PEM encoded certificate chain and its private key: "+err.Error(),
+		)
+		return
+	}
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate_pem"),
+			"Invalid Certificate",
+			"Could not parse the certificate: "+err.Error(),
+		)
+		return
+	}
+	if knownString(config.Hostname) {
+		if err := leaf.VerifyHostname(config.Hostname.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("certificate_pem"),
+				"Invalid Certificate",
+				"The certificate does not cover the hostname: "+err.Error(),
+			)
+		}
+	}
+}
+
+func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan domainResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := r.client.Domains().Create(ctx, domainItem(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating domain",
+			"Could not create domain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	privateKeyPem := plan.PrivateKeyPem
+	plan, diags = newDomainModel(domain)
+	resp.Diagnostics.Append(diags...)
+	plan.PrivateKeyPem = privateKeyPem
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state domainResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := r.client.Domains().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Domain",
+			"Could not read domain ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The domain was deleted outside Terraform, plan to create it again.
+	if domain == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The API never returns the private key, so keep the one in state.
+	privateKeyPem, lastUpdated := state.PrivateKeyPem, state.LastUpdated
+	state, diags = newDomainModel(domain)
+	resp.Diagnostics.Append(diags...)
+	state.PrivateKeyPem, state.LastUpdated = privateKeyPem, lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan domainResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state domainResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := domainItem(plan)
+	item.ID = state.ID.ValueString()
+	domain, err := r.client.Domains().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating domain",
+			"Could not update domain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	privateKeyPem := plan.PrivateKeyPem
+	plan, diags = newDomainModel(domain)
+	resp.Diagnostics.Append(diags...)
+	plan.PrivateKeyPem = privateKeyPem
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *domainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state domainResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Domains().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting domain",
+			"Could not delete domain, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *domainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *domainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func domainItem(plan domainResourceModel) coderforge.Domain {
+	domain := coderforge.Domain{
+		Hostname:        plan.Hostname.ValueString(),
+		CertificateMode: coderforge.CertificateManaged,
+	}
+	if !plan.CertificatePem.IsNull() {
+		domain.CertificateMode = coderforge.CertificateUploaded
+		domain.CertificatePem = plan.CertificatePem.ValueString()
+		domain.PrivateKeyPem = plan.PrivateKeyPem.ValueString()
+	}
+	return domain
+}
+
+// newDomainModel maps a domain to the resource model. The private key is
+// left null, as the API never returns it.
+func newDomainModel(domain *coderforge.Domain) (domainResourceModel, diag.Diagnostics) {
+	records := make([]attr.Value, 0, len(domain.ValidationRecords))
+	for _, record := range domain.ValidationRecords {
+		records = append(records, types.ObjectValueMust(dnsRecordType.AttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(record.Name),
+			"type":  types.StringValue(record.Type),
+			"value": types.StringValue(record.Value),
+		}))
+	}
+	validationRecords, diags := types.ListValue(dnsRecordType, records)
+
+	return domainResourceModel{
+		ID:                types.StringValue(domain.ID),
+		Hostname:          types.StringValue(domain.Hostname),
+		CertificatePem:    stringValueOrNull(domain.CertificatePem),
+		PrivateKeyPem:     types.StringNull(),
+		CertificateStatus: types.StringValue(domain.CertificateStatus),
+		ValidationRecords: validationRecords,
+	}, diags
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDomainResource_managed(t *testing.T) {
+	server := testAccFakeServer(t)
+	server.PendingCertificateReads = 2
+	testAccFastDomainValidation(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "domain"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_domain" "api" {
+  hostname = "api.example.com"
+}
+
+resource "coderforge_domain_validation" "api" {
+  domain_id = coderforge_domain.api.id
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_domain.api", "id"),
+					resource.TestCheckNoResourceAttr("coderforge_domain.api", "certificate_pem"),
+					resource.TestCheckResourceAttr("coderforge_domain.api", "certificate_status", "pending"),
+					resource.TestCheckResourceAttr("coderforge_domain.api", "validation_records.#", "1"),
+					resource.TestCheckResourceAttr("coderforge_domain.api", "validation_records.0.name", "_coderforge-challenge.api.example.com."),
+					resource.TestCheckResourceAttr("coderforge_domain.api", "validation_records.0.type", "CNAME"),
+					resource.TestCheckResourceAttrPair("coderforge_domain_validation.api", "id", "coderforge_domain.api", "id"),
+					resource.TestCheckResourceAttr("coderforge_domain_validation.api", "certificate_status", "issued"),
+					testAccCheckItemField(server, "coderforge_domain.api", "certificateMode", "managed"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_domain.api",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "certificate_status"},
+			},
+		},
+	})
+}
+
+func TestAccDomainResource_uploaded(t *testing.T) {
+	server := testAccFakeServer(t)
+	testAccFastDomainValidation(t)
+
+	config := func(certificatePem, privateKeyPem string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_domain" "www" {
+  hostname        = "www.example.com"
+  certificate_pem = %q
+  private_key_pem = %q
+}
+
+resource "coderforge_domain_validation" "www" {
+  domain_id = coderforge_domain.www.id
+}
+`, certificatePem, privateKeyPem)
+	}
+	certificatePem, privateKeyPem := testAccCertificate(t, "*.example.com")
+	renewedPem, renewedKeyPem := testAccCertificate(t, "www.example.com")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "domain"),
+		Steps: []resource.TestStep{
+			{
+				Config: config(certificatePem, privateKeyPem),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_domain.www", "certificate_pem", certificatePem),
+					resource.TestCheckResourceAttr("coderforge_domain.www", "private_key_pem", privateKeyPem),
+					resource.TestCheckResourceAttr("coderforge_domain.www", "certificate_status", "issued"),
+					resource.TestCheckResourceAttr("coderforge_domain.www", "validation_records.#", "0"),
+					resource.TestCheckResourceAttr("coderforge_domain_validation.www", "certificate_status", "issued"),
+					testAccCheckItemField(server, "coderforge_domain.www", "certificateMode", "uploaded"),
+				),
+			},
+			// The key is not returned by the API, so it cannot be imported.
+			{
+				ResourceName:            "coderforge_domain.www",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "private_key_pem"},
+			},
+			// Upload a renewed certificate in place.
+			{
+				Config: config(renewedPem, renewedKeyPem),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_domain.www", "certificate_pem", renewedPem),
+					testAccCheckItemField(server, "coderforge_domain.www", "certificatePem", renewedPem),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainValidationResource_failed(t *testing.T) {
+	server := testAccFakeServer(t)
+	testAccFastDomainValidation(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "domain"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_domain" "api" {
+  hostname = "api.example.invalid"
+}
+
+resource "coderforge_domain_validation" "api" {
+  domain_id = coderforge_domain.api.id
+}
+`,
+				ExpectError: regexp.MustCompile(`Error Validating Certificate`),
+			},
+		},
+	})
+}
+
+func TestAccDomainResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+	certificatePem, privateKeyPem := testAccCertificate(t, "www.example.com")
+	_, otherKeyPem := testAccCertificate(t, "www.example.com")
+
+	for name, tc := range map[string]struct {
+		domain string
+		err    string
+	}{
+		"bad hostname":     {`hostname = "API.example.com"`, `Invalid Hostname`},
+		"single label":     {`hostname = "localhost"`, `Invalid Hostname`},
+		"certificate only": {fmt.Sprintf("hostname = \"www.example.com\"\n  certificate_pem = %q", certificatePem), `Incomplete Certificate`},
+		"not pem":          {"hostname = \"www.example.com\"\n  certificate_pem = \"cert\"\n  private_key_pem = \"key\"", `Invalid Certificate`},
+		"other key":        {fmt.Sprintf("hostname = \"www.example.com\"\n  certificate_pem = %q\n  private_key_pem = %q", certificatePem, otherKeyPem), `Invalid Certificate`},
+		"other hostname":   {fmt.Sprintf("hostname = \"api.example.com\"\n  certificate_pem = %q\n  private_key_pem = %q", certificatePem, privateKeyPem), `Invalid Certificate`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_domain" "test" {
+  ` + tc.domain + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+// testAccFastDomainValidation polls certificates every millisecond for the
+// rest of the test.
+func testAccFastDomainValidation(t *testing.T) {
+	interval := domainValidationPollInterval
+	domainValidationPollInterval = time.Millisecond
+	t.Cleanup(func() { domainValidationPollInterval = interval })
+}
+
+// testAccCertificate returns a self-signed certificate for hostname and its
+// private key, PEM encoded.
+func testAccCertificate(t *testing.T, hostname string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource              = &domainValidationResource{}
+	_ resource.ResourceWithConfigure = &domainValidationResource{}
+)
+
+const defaultDomainValidationCreateTimeout = 45 * time.Minute
+
+// domainValidationPollInterval is how often the certificate status is read
+// while waiting for it to be issued.
+var domainValidationPollInterval = 10 * time.Second
+
+func NewDomainValidationResource() resource.Resource {
+	return &domainValidationResource{}
+}
+
+// domainValidationResource waits for the certificate of a domain to be
+// issued. It is separate from the domain so that the validation records can
+// be published in DNS between the two.
+type domainValidationResource struct {
+	client *coderforge.Client
+}
+
+type domainValidationResourceModel struct {
+	ID                types.String   `tfsdk:"id"`
+	DomainId          types.String   `tfsdk:"domain_id"`
+	CertificateStatus types.String   `tfsdk:"certificate_status"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *domainValidationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_validation"
+}
+
+func (r *domainValidationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"certificate_status": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// Create waits until the certificate of the domain is issued.
+func (r *domainValidationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan domainValidationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultDomainValidationCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	domain, err := r.client.WaitForCertificate(ctx, plan.DomainId.ValueString(), domainValidationPollInterval)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Validating Certificate",
+			"The certificate of domain "+plan.DomainId.ValueString()+" was not issued: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(domain.ID)
+	plan.CertificateStatus = types.StringValue(domain.CertificateStatus)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *domainValidationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state domainValidationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := r.client.Domains().Get(ctx, state.DomainId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Domain",
+			"Could not read domain ID "+state.DomainId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The domain was deleted or its certificate is no longer issued, plan
+	// to wait for it again.
+	if domain == nil || domain.CertificateStatus != coderforge.CertificateIssued {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.CertificateStatus = types.StringValue(domain.CertificateStatus)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes the timeouts, as every other change replaces the
+// validation.
+func (r *domainValidationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan domainValidationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete only removes the validation from state, the domain keeps its
+// certificate.
+func (r *domainValidationResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *domainValidationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
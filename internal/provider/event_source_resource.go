@@ -0,0 +1,387 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &eventSourceResource{}
+	_ resource.ResourceWithConfigure      = &eventSourceResource{}
+	_ resource.ResourceWithImportState    = &eventSourceResource{}
+	_ resource.ResourceWithValidateConfig = &eventSourceResource{}
+)
+
+// Limits of the event source settings.
+const (
+	maxEventSourceBatchSize   = 1000
+	maxEventSourceMaxAttempts = 10
+)
+
+func NewEventSourceResource() resource.Resource {
+	return &eventSourceResource{}
+}
+
+type eventSourceResourceModel struct {
+	ID              types.String      `tfsdk:"id"`
+	Name            types.String      `tfsdk:"name"`
+	Type            types.String      `tfsdk:"type"`
+	FunctionId      types.String      `tfsdk:"function_id"`
+	Queue           types.String      `tfsdk:"queue"`
+	Topic           types.String      `tfsdk:"topic"`
+	BatchSize       types.Int64       `tfsdk:"batch_size"`
+	RetryPolicy     *retryPolicyModel `tfsdk:"retry_policy"`
+	DeadLetterQueue types.String      `tfsdk:"dead_letter_queue"`
+	Enabled         types.Bool        `tfsdk:"enabled"`
+	WebhookURL      types.String      `tfsdk:"webhook_url"`
+	WebhookSecret   types.String      `tfsdk:"webhook_secret"`
+	LastUpdated     types.String      `tfsdk:"last_updated"`
+}
+
+type retryPolicyModel struct {
+	MaxAttempts types.Int64  `tfsdk:"max_attempts"`
+	Backoff     types.String `tfsdk:"backoff"`
+}
+
+type eventSourceResource struct {
+	client *coderforge.Client
+}
+
+func (r *eventSourceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_event_source"
+}
+
+func (r *eventSourceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"function_id": schema.StringAttribute{
+				Required: true,
+			},
+			"queue": schema.StringAttribute{
+				Optional: true,
+			},
+			"topic": schema.StringAttribute{
+				Optional: true,
+			},
+			"batch_size": schema.Int64Attribute{
+				Optional: true,
+			},
+			"retry_policy": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						Required: true,
+					},
+					"backoff": schema.StringAttribute{
+						Optional: true,
+					},
+				},
+			},
+			"dead_letter_queue": schema.StringAttribute{
+				Optional: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"webhook_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"webhook_secret": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the attributes set match the source type.
+func (r *eventSourceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config eventSourceResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if knownString(config.Type) {
+		var required string
+		var forbidden []string
+		switch config.Type.ValueString() {
+		case coderforge.EventSourceQueue:
+			required, forbidden = "queue", []string{"topic"}
+		case coderforge.EventSourceTopic:
+			required, forbidden = "topic", []string{"queue"}
+		case coderforge.EventSourceWebhook:
+			forbidden = []string{"queue", "topic", "batch_size"}
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("type"),
+				"Invalid Event Source Type",
+				"The type must be one of queue, topic or webhook, got: "+config.Type.ValueString(),
+			)
+			return
+		}
+
+		set := map[string]bool{
+			"queue":      !config.Queue.IsNull(),
+			"topic":      !config.Topic.IsNull(),
+			"batch_size": !config.BatchSize.IsNull(),
+		}
+		if required != "" && !set[required] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(required),
+				"Missing Event Source Attribute",
+				fmt.Sprintf("A %s event source requires %s.", config.Type.ValueString(), required),
+			)
+		}
+		for _, name := range forbidden {
+			if set[name] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name),
+					"Unexpected Event Source Attribute",
+					fmt.Sprintf("A %s event source does not support %s.", config.Type.ValueString(), name),
+				)
+			}
+		}
+	}
+
+	if batchSize := config.BatchSize; !batchSize.IsNull() && !batchSize.IsUnknown() {
+		if batchSize.ValueInt64() < 1 || batchSize.ValueInt64() > maxEventSourceBatchSize {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("batch_size"),
+				"Invalid Batch Size",
+				fmt.Sprintf("The batch_size must be between 1 and %d, got: %d.", maxEventSourceBatchSize, batchSize.ValueInt64()),
+			)
+		}
+	}
+
+	if config.RetryPolicy != nil {
+		maxAttempts := config.RetryPolicy.MaxAttempts
+		if !maxAttempts.IsNull() && !maxAttempts.IsUnknown() && (maxAttempts.ValueInt64() < 0 || maxAttempts.ValueInt64() > maxEventSourceMaxAttempts) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_policy").AtName("max_attempts"),
+				"Invalid Retry Policy",
+				fmt.Sprintf("The max_attempts must be between 0 and %d, got: %d.", maxEventSourceMaxAttempts, maxAttempts.ValueInt64()),
+			)
+		}
+		backoff := config.RetryPolicy.Backoff
+		if knownString(backoff) && backoff.ValueString() != "fixed" && backoff.ValueString() != "exponential" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_policy").AtName("backoff"),
+				"Invalid Retry Policy",
+				"The backoff must be fixed or exponential, got: "+backoff.ValueString(),
+			)
+		}
+	}
+}
+
+func (r *eventSourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan eventSourceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	eventSource, err := r.client.EventSources().Create(ctx, eventSourceItem(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating event source",
+			"Could not create event source, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newEventSourceModel(eventSource)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *eventSourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state eventSourceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	eventSource, err := r.client.EventSources().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Event Source",
+			"Could not read event source ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The event source was deleted outside Terraform, plan to create it
+	// again.
+	if eventSource == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state = newEventSourceModel(eventSource)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *eventSourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan eventSourceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state eventSourceResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := eventSourceItem(plan)
+	item.ID = state.ID.ValueString()
+	eventSource, err := r.client.EventSources().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating event source",
+			"Could not update event source, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newEventSourceModel(eventSource)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *eventSourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state eventSourceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.EventSources().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting event source",
+			"Could not delete event source, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *eventSourceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *eventSourceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func eventSourceItem(plan eventSourceResourceModel) coderforge.EventSource {
+	eventSource := coderforge.EventSource{
+		Name:            plan.Name.ValueString(),
+		SourceType:      plan.Type.ValueString(),
+		FunctionId:      plan.FunctionId.ValueString(),
+		Queue:           plan.Queue.ValueString(),
+		Topic:           plan.Topic.ValueString(),
+		BatchSize:       plan.BatchSize.ValueInt64(),
+		DeadLetterQueue: plan.DeadLetterQueue.ValueString(),
+		Enabled:         plan.Enabled.ValueBool(),
+	}
+	if plan.RetryPolicy != nil {
+		eventSource.RetryPolicy = &coderforge.RetryPolicy{
+			MaxAttempts: plan.RetryPolicy.MaxAttempts.ValueInt64(),
+			Backoff:     plan.RetryPolicy.Backoff.ValueString(),
+		}
+	}
+	return eventSource
+}
+
+func newEventSourceModel(eventSource *coderforge.EventSource) eventSourceResourceModel {
+	model := eventSourceResourceModel{
+		ID:              types.StringValue(eventSource.ID),
+		Name:            types.StringValue(eventSource.Name),
+		Type:            types.StringValue(eventSource.SourceType),
+		FunctionId:      types.StringValue(eventSource.FunctionId),
+		Queue:           stringValueOrNull(eventSource.Queue),
+		Topic:           stringValueOrNull(eventSource.Topic),
+		BatchSize:       int64ValueOrNull(eventSource.BatchSize),
+		DeadLetterQueue: stringValueOrNull(eventSource.DeadLetterQueue),
+		Enabled:         types.BoolValue(eventSource.Enabled),
+		WebhookURL:      stringValueOrNull(eventSource.WebhookURL),
+		WebhookSecret:   stringValueOrNull(eventSource.WebhookSecret),
+	}
+	if eventSource.RetryPolicy != nil {
+		model.RetryPolicy = &retryPolicyModel{
+			MaxAttempts: types.Int64Value(eventSource.RetryPolicy.MaxAttempts),
+			Backoff:     stringValueOrNull(eventSource.RetryPolicy.Backoff),
+		}
+	}
+	return model
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccEventSourceResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "event_source"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+resource "coderforge_event_source" "orders" {
+  name              = "orders"
+  type              = "queue"
+  function_id       = coderforge_function.test.id
+  queue             = "orders"
+  batch_size        = 10
+  dead_letter_queue = "orders-failed"
+
+  retry_policy = {
+    max_attempts = 3
+    backoff      = "exponential"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_event_source.orders", "id"),
+					resource.TestCheckResourceAttr("coderforge_event_source.orders", "batch_size", "10"),
+					resource.TestCheckResourceAttr("coderforge_event_source.orders", "retry_policy.max_attempts", "3"),
+					resource.TestCheckResourceAttr("coderforge_event_source.orders", "enabled", "true"),
+					resource.TestCheckNoResourceAttr("coderforge_event_source.orders", "webhook_url"),
+					testAccCheckItemField(server, "coderforge_event_source.orders", "type", "event_source"),
+					testAccCheckItemField(server, "coderforge_event_source.orders", "deadLetterQueue", "orders-failed"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_event_source.orders",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Drop the retry policy and pause the source.
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+resource "coderforge_event_source" "orders" {
+  name        = "orders"
+  type        = "queue"
+  function_id = coderforge_function.test.id
+  queue       = "orders"
+  enabled     = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("coderforge_event_source.orders", "retry_policy.max_attempts"),
+					resource.TestCheckNoResourceAttr("coderforge_event_source.orders", "batch_size"),
+					resource.TestCheckResourceAttr("coderforge_event_source.orders", "enabled", "false"),
+					testAccCheckItemField(server, "coderforge_event_source.orders", "enabled", false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEventSourceResource_webhook(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	config := func(enabled string) string {
+		return testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+resource "coderforge_event_source" "github" {
+  name        = "github"
+  type        = "webhook"
+  function_id = coderforge_function.test.id
+  enabled     = ` + enabled + `
+}
+`
+	}
+
+	var secret string
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "event_source"),
+		Steps: []resource.TestStep{
+			{
+				Config: config("true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("coderforge_event_source.github", "webhook_url", regexp.MustCompile(`^https://hooks\.coderforge\.org/`)),
+					resource.TestCheckResourceAttrWith("coderforge_event_source.github", "webhook_secret", func(value string) error {
+						secret = value
+						return nil
+					}),
+				),
+			},
+			// The secret is kept across updates.
+			{
+				Config: config("false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_event_source.github", "enabled", "false"),
+					resource.TestCheckResourceAttrWith("coderforge_event_source.github", "webhook_secret", func(value string) error {
+						if value == "" || value != secret {
+							return fmt.Errorf("webhook_secret changed from %q to %q", secret, value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEventSourceResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		eventSource string
+		err         string
+	}{
+		"unknown type":        {`type = "stream"`, `Invalid Event Source Type`},
+		"queue without queue": {`type = "queue"`, `requires queue`},
+		"queue with topic":    {"type = \"queue\"\n  queue = \"a\"\n  topic = \"b\"", `does not support topic`},
+		"topic without topic": {`type = "topic"`, `requires topic`},
+		"webhook batch size":  {"type = \"webhook\"\n  batch_size = 10", `does not support batch_size`},
+		"batch size too big":  {"type = \"queue\"\n  queue = \"a\"\n  batch_size = 1001", `Invalid Batch Size`},
+		"too many attempts":   {"type = \"queue\"\n  queue = \"a\"\n  retry_policy = { max_attempts = 11 }", `Invalid Retry Policy`},
+		"bad backoff":         {"type = \"queue\"\n  queue = \"a\"\n  retry_policy = { max_attempts = 1, backoff = \"linear\" }", `Invalid Retry Policy`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_event_source" "test" {
+  name        = "test"
+  function_id = "function-1"
+  ` + tc.eventSource + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
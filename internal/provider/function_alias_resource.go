@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &functionAliasResource{}
+	_ resource.ResourceWithConfigure      = &functionAliasResource{}
+	_ resource.ResourceWithImportState    = &functionAliasResource{}
+	_ resource.ResourceWithValidateConfig = &functionAliasResource{}
+)
+
+func NewFunctionAliasResource() resource.Resource {
+	return &functionAliasResource{}
+}
+
+type functionAliasResourceModel struct {
+	ID              types.String               `tfsdk:"id"`
+	Name            types.String               `tfsdk:"name"`
+	FunctionId      types.String               `tfsdk:"function_id"`
+	FunctionVersion types.Int64                `tfsdk:"function_version"`
+	Routing         *functionAliasRoutingModel `tfsdk:"routing"`
+	LastUpdated     types.String               `tfsdk:"last_updated"`
+}
+
+// functionAliasRoutingModel sends weight percent of the requests to
+// additional_version and the rest to function_version.
+type functionAliasRoutingModel struct {
+	AdditionalVersion types.Int64 `tfsdk:"additional_version"`
+	Weight            types.Int64 `tfsdk:"weight"`
+}
+
+type functionAliasResource struct {
+	client *coderforge.Client
+}
+
+func (r *functionAliasResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_function_alias"
+}
+
+func (r *functionAliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"function_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"function_version": schema.Int64Attribute{
+				Required: true,
+			},
+			"routing": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"additional_version": schema.Int64Attribute{
+						Required: true,
+					},
+					"weight": schema.Int64Attribute{
+						Required: true,
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the versions and the weight at plan time.
+func (r *functionAliasResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config functionAliasResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.FunctionVersion.IsUnknown() && !config.FunctionVersion.IsNull() && config.FunctionVersion.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("function_version"),
+			"Invalid Function Version",
+			"Published versions are numbered from 1.",
+		)
+	}
+	if config.Routing == nil {
+		return
+	}
+	additional := config.Routing.AdditionalVersion
+	if !additional.IsUnknown() && !additional.IsNull() {
+		if additional.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("routing").AtName("additional_version"),
+				"Invalid Function Version",
+				"Published versions are numbered from 1.",
+			)
+		}
+		if !config.FunctionVersion.IsUnknown() && additional.ValueInt64() == config.FunctionVersion.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("routing").AtName("additional_version"),
+				"Invalid Function Version",
+				"The additional_version must differ from function_version.",
+			)
+		}
+	}
+	weight := config.Routing.Weight
+	if !weight.IsUnknown() && !weight.IsNull() && (weight.ValueInt64() < 1 || weight.ValueInt64() > 99) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("routing").AtName("weight"),
+			"Invalid Routing Weight",
+			fmt.Sprintf("The weight is the percentage of requests sent to additional_version and must be between 1 and 99, got: %d.", weight.ValueInt64()),
+		)
+	}
+}
+
+func (r *functionAliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan functionAliasResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alias, err := r.client.FunctionAliases().Create(ctx, functionAliasItem(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating function alias",
+			"Could not create function alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newFunctionAliasModel(alias)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *functionAliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state functionAliasResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alias, err := r.client.FunctionAliases().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Function Alias",
+			"Could not read function alias ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The alias was deleted outside Terraform, plan to create it again.
+	if alias == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state = newFunctionAliasModel(alias)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *functionAliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan functionAliasResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state functionAliasResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := functionAliasItem(plan)
+	item.ID = state.ID.ValueString()
+	alias, err := r.client.FunctionAliases().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating function alias",
+			"Could not update function alias, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newFunctionAliasModel(alias)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *functionAliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state functionAliasResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.FunctionAliases().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting function alias",
+			"Could not delete function alias, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *functionAliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *functionAliasResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func functionAliasItem(plan functionAliasResourceModel) coderforge.FunctionAlias {
+	alias := coderforge.FunctionAlias{
+		Name:            plan.Name.ValueString(),
+		FunctionId:      plan.FunctionId.ValueString(),
+		FunctionVersion: plan.FunctionVersion.ValueInt64(),
+	}
+	if plan.Routing != nil {
+		alias.AdditionalVersion = plan.Routing.AdditionalVersion.ValueInt64()
+		alias.AdditionalVersionWeight = plan.Routing.Weight.ValueInt64()
+	}
+	return alias
+}
+
+func newFunctionAliasModel(alias *coderforge.FunctionAlias) functionAliasResourceModel {
+	model := functionAliasResourceModel{
+		ID:              types.StringValue(alias.ID),
+		Name:            types.StringValue(alias.Name),
+		FunctionId:      types.StringValue(alias.FunctionId),
+		FunctionVersion: types.Int64Value(alias.FunctionVersion),
+	}
+	if alias.AdditionalVersion != 0 {
+		model.Routing = &functionAliasRoutingModel{
+			AdditionalVersion: types.Int64Value(alias.AdditionalVersion),
+			Weight:            types.Int64Value(alias.AdditionalVersionWeight),
+		}
+	}
+	return model
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFunctionAliasResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "function_alias"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionAliasResourceConfig("hello:1", "coderforge_function.test.version", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "version", "1"),
+					resource.TestCheckResourceAttrSet("coderforge_function_alias.live", "id"),
+					resource.TestCheckResourceAttr("coderforge_function_alias.live", "name", "live"),
+					resource.TestCheckResourceAttr("coderforge_function_alias.live", "function_version", "1"),
+					resource.TestCheckNoResourceAttr("coderforge_function_alias.live", "routing"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_function_alias.live",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Publish a canary and send a tenth of the requests to it.
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionAliasResourceConfig("hello:2", "1", `
+  routing = {
+    additional_version = coderforge_function.test.version
+    weight             = 10
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "version", "2"),
+					resource.TestCheckResourceAttr("coderforge_function_alias.live", "function_version", "1"),
+					resource.TestCheckResourceAttr("coderforge_function_alias.live", "routing.additional_version", "2"),
+					resource.TestCheckResourceAttr("coderforge_function_alias.live", "routing.weight", "10"),
+				),
+			},
+			// Roll back by pointing the alias at the previous version.
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionAliasResourceConfig("hello:2", "1", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "version", "2"),
+					resource.TestCheckResourceAttr("coderforge_function_alias.live", "function_version", "1"),
+					resource.TestCheckNoResourceAttr("coderforge_function_alias.live", "routing"),
+				),
+			},
+			// The API refuses versions that were never published.
+			{
+				Config:      testAccProviderConfig(server) + testAccFunctionAliasResourceConfig("hello:2", "7", ""),
+				ExpectError: regexp.MustCompile(`has no published version 7`),
+			},
+		},
+	})
+}
+
+func TestAccFunctionAliasResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_function_alias" "live" {
+  name             = "live"
+  function_id      = "function-1"
+  function_version = 2
+  routing = {
+    additional_version = 3
+    weight             = 150
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`between 1 and 99`),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_function_alias" "live" {
+  name             = "live"
+  function_id      = "function-1"
+  function_version = 2
+  routing = {
+    additional_version = 2
+    weight             = 10
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`must differ from function_version`),
+			},
+		},
+	})
+}
+
+func testAccFunctionAliasResourceConfig(image string, version string, routing string) string {
+	return fmt.Sprintf(`
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/%[1]s"
+  }
+  publish = true
+}
+
+resource "coderforge_function_alias" "live" {
+  name             = "live"
+  function_id      = coderforge_function.test.id
+  function_version = %[2]s
+%[3]s}
+`, image, version, routing)
+}
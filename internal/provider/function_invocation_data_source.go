@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ datasource.DataSource                   = &functionInvocationDataSource{}
+	_ datasource.DataSourceWithConfigure      = &functionInvocationDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &functionInvocationDataSource{}
+)
+
+func NewFunctionInvocationDataSource() datasource.DataSource {
+	return &functionInvocationDataSource{}
+}
+
+type functionInvocationDataSourceModel struct {
+	FunctionId types.String `tfsdk:"function_id"`
+	Payload    types.String `tfsdk:"payload"`
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	Body       types.String `tfsdk:"body"`
+	DurationMs types.Int64  `tfsdk:"duration_ms"`
+}
+
+type functionInvocationDataSource struct {
+	client *coderforge.Client
+}
+
+func (d *functionInvocationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_function_invocation"
+}
+
+func (d *functionInvocationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				Required: true,
+			},
+			"payload": schema.StringAttribute{
+				Optional: true,
+			},
+			"status_code": schema.Int64Attribute{
+				Computed: true,
+			},
+			"body": schema.StringAttribute{
+				Computed: true,
+			},
+			"duration_ms": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig rejects a payload that is not JSON before anything is
+// invoked.
+func (d *functionInvocationDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config functionInvocationDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Payload.IsNull() || config.Payload.IsUnknown() {
+		return
+	}
+	if !json.Valid([]byte(config.Payload.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("payload"),
+			"Invalid Function Payload",
+			"The payload must be a JSON document, for example built with jsonencode().",
+		)
+	}
+}
+
+// Read invokes the function every time the data source is read, so the
+// result reflects the function as currently deployed. A function that
+// answers with an error status is not a Terraform error; check status_code,
+// for example in a check block.
+func (d *functionInvocationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state functionInvocationDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var payload json.RawMessage
+	if !state.Payload.IsNull() {
+		payload = json.RawMessage(state.Payload.ValueString())
+	}
+
+	invocation, err := d.client.Invoke(ctx, state.FunctionId.ValueString(), payload)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Invoking Function",
+			"Could not invoke function "+state.FunctionId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.StatusCode = types.Int64Value(invocation.StatusCode)
+	state.Body = types.StringValue(invocation.Body)
+	state.DurationMs = types.Int64Value(invocation.DurationMs)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *functionInvocationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFunctionInvocationDataSource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+data "coderforge_function_invocation" "smoke" {
+  function_id = coderforge_function.test.id
+  payload     = jsonencode({ name = "smoke" })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderforge_function_invocation.smoke", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.coderforge_function_invocation.smoke", "body", `{"functionName":"helloWorld","payload":{"name":"smoke"}}`),
+					resource.TestCheckResourceAttrSet("data.coderforge_function_invocation.smoke", "duration_ms"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFunctionInvocationDataSource_invalidPayload(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "coderforge_function_invocation" "smoke" {
+  function_id = "function-1"
+  payload     = "{not json"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Function Payload`),
+			},
+		},
+	})
+	if n := len(server.Requests()); n != 0 {
+		t.Fatalf("expected nothing to be invoked, got %v", server.Requests())
+	}
+}
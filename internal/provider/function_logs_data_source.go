@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ datasource.DataSource                   = &functionLogsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &functionLogsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &functionLogsDataSource{}
+)
+
+// Number of log lines read when limit is not set, and at most.
+const (
+	defaultFunctionLogsLimit = 100
+	maxFunctionLogsLimit     = 1000
+)
+
+func NewFunctionLogsDataSource() datasource.DataSource {
+	return &functionLogsDataSource{}
+}
+
+type functionLogsDataSourceModel struct {
+	FunctionId types.String   `tfsdk:"function_id"`
+	Limit      types.Int64    `tfsdk:"limit"`
+	Lines      []logLineModel `tfsdk:"lines"`
+	Text       types.String   `tfsdk:"text"`
+}
+
+type logLineModel struct {
+	Timestamp types.String `tfsdk:"timestamp"`
+	Level     types.String `tfsdk:"level"`
+	Message   types.String `tfsdk:"message"`
+}
+
+type functionLogsDataSource struct {
+	client *coderforge.Client
+}
+
+func (d *functionLogsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_function_logs"
+}
+
+func (d *functionLogsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"function_id": schema.StringAttribute{
+				Required: true,
+			},
+			"limit": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+			},
+			"lines": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"timestamp": schema.StringAttribute{
+							Computed: true,
+						},
+						"level": schema.StringAttribute{
+							Computed: true,
+						},
+						"message": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+			// text is the lines formatted one per line, for printing.
+			"text": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the number of lines asked for.
+func (d *functionLogsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config functionLogsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := config.Limit
+	if !limit.IsNull() && !limit.IsUnknown() && (limit.ValueInt64() < 1 || limit.ValueInt64() > maxFunctionLogsLimit) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("limit"),
+			"Invalid Log Limit",
+			fmt.Sprintf("The limit must be between 1 and %d, got: %d.", maxFunctionLogsLimit, limit.ValueInt64()),
+		)
+	}
+}
+
+// Read fetches the last lines the function logged, oldest first.
+func (d *functionLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state functionLogsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Limit.IsNull() {
+		state.Limit = types.Int64Value(defaultFunctionLogsLimit)
+	}
+
+	lines, err := d.client.FunctionLogs(ctx, state.FunctionId.ValueString(), state.Limit.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Function Logs",
+			"Could not read the logs of function "+state.FunctionId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	var text strings.Builder
+	state.Lines = make([]logLineModel, 0, len(lines))
+	for _, line := range lines {
+		state.Lines = append(state.Lines, logLineModel{
+			Timestamp: types.StringValue(line.Timestamp),
+			Level:     types.StringValue(line.Level),
+			Message:   types.StringValue(line.Message),
+		})
+		fmt.Fprintf(&text, "%s %s %s\n", line.Timestamp, strings.ToUpper(line.Level), line.Message)
+	}
+	state.Text = types.StringValue(text.String())
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *functionLogsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
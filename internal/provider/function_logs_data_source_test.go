@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFunctionLogsDataSource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	config := testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+data "coderforge_function_logs" "recent" {
+  function_id = coderforge_function.test.id
+  limit       = 2
+}
+
+data "coderforge_function_logs" "default" {
+  function_id = coderforge_function.test.id
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function"),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderforge_function_logs.recent", "lines.#", "0"),
+					resource.TestCheckResourceAttr("data.coderforge_function_logs.recent", "text", ""),
+					resource.TestCheckResourceAttr("data.coderforge_function_logs.default", "limit", "100"),
+				),
+			},
+			{
+				PreConfig: func() {
+					for _, item := range server.Resources() {
+						if item["type"] == "function" {
+							server.Log(item.ID(), "info", "listening on :8080")
+							server.Log(item.ID(), "warn", "slow request")
+							server.Log(item.ID(), "error", "connection refused")
+						}
+					}
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderforge_function_logs.recent", "lines.#", "2"),
+					resource.TestCheckResourceAttr("data.coderforge_function_logs.recent", "lines.0.level", "warn"),
+					resource.TestCheckResourceAttr("data.coderforge_function_logs.recent", "lines.1.message", "connection refused"),
+					resource.TestCheckResourceAttr("data.coderforge_function_logs.recent", "text",
+						"2024-01-01T00:00:01Z WARN slow request\n2024-01-01T00:00:02Z ERROR connection refused\n"),
+					resource.TestCheckResourceAttr("data.coderforge_function_logs.default", "lines.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFunctionLogsDataSource_invalidLimit(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "coderforge_function_logs" "recent" {
+  function_id = "function-1"
+  limit       = 0
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Log Limit`),
+			},
+		},
+	})
+}
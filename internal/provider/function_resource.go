@@ -3,16 +3,40 @@ package provider
 import (
 	"context"
 	"fmt"
+	pathpkg "path"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
 )
 
 var (
-	_ resource.Resource              = &functionResource{}
-	_ resource.ResourceWithConfigure = &functionResource{}
+	_ resource.Resource                   = &functionResource{}
+	_ resource.ResourceWithConfigure      = &functionResource{}
+	_ resource.ResourceWithImportState    = &functionResource{}
+	_ resource.ResourceWithModifyPlan     = &functionResource{}
+	_ resource.ResourceWithValidateConfig = &functionResource{}
+)
+
+const (
+	defaultFunctionCreateTimeout = 20 * time.Minute
+	defaultFunctionReadTimeout   = 5 * time.Minute
+	defaultFunctionUpdateTimeout = 20 * time.Minute
+	defaultFunctionDeleteTimeout = 10 * time.Minute
 )
 
 func NewFunctionResource() resource.Resource {
@@ -20,12 +44,19 @@ func NewFunctionResource() resource.Resource {
 }
 
 type functionResourceModel struct {
-	ID           types.String      `tfsdk:"id"`
-	FunctionName types.String      `tfsdk:"function_name"`
-	Code         functionCodeModel `tfsdk:"code"`
-	Timeout      types.Int64       `tfsdk:"timeout"`
-	MaxRamSize   types.String      `tfsdk:"max_ram_size"`
-	LastUpdated  types.String      `tfsdk:"last_updated"`
+	ID           types.String            `tfsdk:"id"`
+	FunctionName types.String            `tfsdk:"function_name"`
+	Code         *functionCodeModel      `tfsdk:"code"`
+	Container    *functionContainerModel `tfsdk:"container"`
+	Timeout      types.Int64             `tfsdk:"timeout"`
+	MaxRamSize   types.String            `tfsdk:"max_ram_size"`
+	Secrets      types.Map               `tfsdk:"secrets"`
+	Network      *functionNetworkModel   `tfsdk:"network"`
+	Mounts       []functionMountModel    `tfsdk:"mounts"`
+	Publish      types.Bool              `tfsdk:"publish"`
+	Version      types.Int64             `tfsdk:"version"`
+	LastUpdated  types.String            `tfsdk:"last_updated"`
+	Timeouts     timeouts.Value          `tfsdk:"timeouts"`
 }
 
 type functionCodeModel struct {
@@ -33,15 +64,64 @@ type functionCodeModel struct {
 	ImageUri    types.String `tfsdk:"image_uri"`
 }
 
+// functionContainerModel overrides how a container image starts and is
+// probed.
+type functionContainerModel struct {
+	Command     types.List        `tfsdk:"command"`
+	Args        types.List        `tfsdk:"args"`
+	WorkingDir  types.String      `tfsdk:"working_dir"`
+	Port        types.Int64       `tfsdk:"port"`
+	HealthCheck *healthCheckModel `tfsdk:"health_check"`
+}
+
+type healthCheckModel struct {
+	Type               types.String `tfsdk:"type"`
+	Path               types.String `tfsdk:"path"`
+	Port               types.Int64  `tfsdk:"port"`
+	IntervalSeconds    types.Int64  `tfsdk:"interval_seconds"`
+	TimeoutSeconds     types.Int64  `tfsdk:"timeout_seconds"`
+	HealthyThreshold   types.Int64  `tfsdk:"healthy_threshold"`
+	UnhealthyThreshold types.Int64  `tfsdk:"unhealthy_threshold"`
+}
+
+// Defaults and limits of health checks.
+const (
+	defaultHealthCheckInterval           = 10
+	defaultHealthCheckTimeout            = 5
+	defaultHealthCheckHealthyThreshold   = 1
+	defaultHealthCheckUnhealthyThreshold = 3
+	maxHealthCheckInterval               = 300
+	maxHealthCheckThreshold              = 10
+)
+
+type functionNetworkModel struct {
+	NetworkId types.String `tfsdk:"network_id"`
+	Subnets   types.List   `tfsdk:"subnets"`
+	Egress    types.String `tfsdk:"egress"`
+}
+
+type functionMountModel struct {
+	VolumeId  types.String `tfsdk:"volume_id"`
+	MountPath types.String `tfsdk:"mount_path"`
+	ReadOnly  types.Bool   `tfsdk:"read_only"`
+}
+
+// reservedMountPaths are managed by the platform and cannot be mounted over.
+var reservedMountPaths = []string{"/dev", "/proc", "/sys", "/tmp"}
+
+// egressModes are the outbound connections a function attached to a network
+// may open.
+var egressModes = []string{coderforge.EgressAll, coderforge.EgressPrivateOnly, coderforge.EgressNone}
+
 type functionResource struct {
-	client *Client
+	client *coderforge.Client
 }
 
 func (r *functionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_function"
 }
 
-func (r *functionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *functionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -67,6 +147,65 @@ func (r *functionResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 					},
 				},
 			},
+			// container overrides how a container_image package starts, and
+			// how the platform checks that it is ready.
+			"container": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					// command replaces the entrypoint of the image, and args
+					// its command.
+					"command": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"args": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"working_dir": schema.StringAttribute{
+						Optional: true,
+					},
+					// port is the port the container listens on.
+					"port": schema.Int64Attribute{
+						Optional: true,
+					},
+					"health_check": schema.SingleNestedAttribute{
+						Optional: true,
+						Attributes: map[string]schema.Attribute{
+							"type": schema.StringAttribute{
+								Required: true,
+							},
+							"path": schema.StringAttribute{
+								Optional: true,
+							},
+							// port defaults to the port of the container.
+							"port": schema.Int64Attribute{
+								Optional: true,
+							},
+							"interval_seconds": schema.Int64Attribute{
+								Optional: true,
+								Computed: true,
+								Default:  int64default.StaticInt64(defaultHealthCheckInterval),
+							},
+							"timeout_seconds": schema.Int64Attribute{
+								Optional: true,
+								Computed: true,
+								Default:  int64default.StaticInt64(defaultHealthCheckTimeout),
+							},
+							"healthy_threshold": schema.Int64Attribute{
+								Optional: true,
+								Computed: true,
+								Default:  int64default.StaticInt64(defaultHealthCheckHealthyThreshold),
+							},
+							"unhealthy_threshold": schema.Int64Attribute{
+								Optional: true,
+								Computed: true,
+								Default:  int64default.StaticInt64(defaultHealthCheckUnhealthyThreshold),
+							},
+						},
+					},
+				},
+			},
 			"timeout": schema.Int64Attribute{
 				Computed: false,
 				Optional: true,
@@ -75,10 +214,402 @@ func (r *functionResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Computed: false,
 				Optional: true,
 			},
+			// secrets maps environment variable names to the names of the
+			// secrets the function receives in them.
+			"secrets": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			// network attaches the function to subnets of a network, which
+			// must span the locations of the function.
+			"network": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"network_id": schema.StringAttribute{
+						Required: true,
+					},
+					"subnets": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"egress": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  stringdefault.StaticString(coderforge.EgressAll),
+					},
+				},
+			},
+			"mounts": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"volume_id": schema.StringAttribute{
+							Required: true,
+						},
+						"mount_path": schema.StringAttribute{
+							Required: true,
+						},
+						"read_only": schema.BoolAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+			"publish": schema.BoolAttribute{
+				Optional: true,
+			},
+			"version": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// ValidateConfig checks the container options, the environment variable
+// names secrets are bound to, the egress mode and the mount paths.
+func (r *functionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config functionResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Container != nil {
+		validateFunctionContainer(config.Code, config.Container, &resp.Diagnostics)
+	}
+
+	for name := range config.Secrets.Elements() {
+		if !envVarPattern.MatchString(name) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secrets").AtMapKey(name),
+				"Invalid Environment Variable Name",
+				"Secrets are bound to environment variables, so the keys of secrets must be environment variable names, got: "+name,
+			)
+		}
+	}
+
+	if config.Network != nil && knownString(config.Network.Egress) && !slices.Contains(egressModes, config.Network.Egress.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("network").AtName("egress"),
+			"Invalid Egress Mode",
+			"The egress must be all, private_only or none, got: "+config.Network.Egress.ValueString(),
+		)
+	}
+
+	validateMountPaths(config.Mounts, &resp.Diagnostics)
+}
+
+// validateFunctionContainer checks that container options are only set for
+// container images, and that they are in range.
+func validateFunctionContainer(code *functionCodeModel, container *functionContainerModel, diags *diag.Diagnostics) {
+	containerPath := path.Root("container")
+	if code != nil && knownString(code.PackageType) && code.PackageType.ValueString() != coderforge.PackageTypeContainerImage {
+		diags.AddAttributeError(
+			containerPath,
+			"Invalid Container Options",
+			"The container options can only be set for the container_image package type, got: "+code.PackageType.ValueString(),
+		)
+	}
+
+	if knownString(container.WorkingDir) && !strings.HasPrefix(container.WorkingDir.ValueString(), "/") {
+		diags.AddAttributeError(
+			containerPath.AtName("working_dir"),
+			"Invalid Working Directory",
+			"The working_dir must be an absolute path, got: "+container.WorkingDir.ValueString(),
+		)
+	}
+	validatePort(container.Port, containerPath.AtName("port"), diags)
+
+	check := container.HealthCheck
+	if check == nil {
+		return
+	}
+	checkPath := containerPath.AtName("health_check")
+	switch {
+	case !knownString(check.Type):
+	case check.Type.ValueString() == coderforge.HealthCheckHTTP:
+		if check.Path.IsNull() {
+			diags.AddAttributeError(
+				checkPath.AtName("path"),
+				"Missing Health Check Path",
+				"HTTP health checks need the path to request.",
+			)
+		}
+	case check.Type.ValueString() == coderforge.HealthCheckTCP:
+		if !check.Path.IsNull() {
+			diags.AddAttributeError(
+				checkPath.AtName("path"),
+				"Invalid Health Check Path",
+				"TCP health checks only connect to the port, so they take no path.",
+			)
+		}
+	default:
+		diags.AddAttributeError(
+			checkPath.AtName("type"),
+			"Invalid Health Check Type",
+			"The type must be http or tcp, got: "+check.Type.ValueString(),
+		)
+	}
+	if knownString(check.Path) && !strings.HasPrefix(check.Path.ValueString(), "/") {
+		diags.AddAttributeError(
+			checkPath.AtName("path"),
+			"Invalid Health Check Path",
+			"The path must start with /, got: "+check.Path.ValueString(),
+		)
+	}
+	validatePort(check.Port, checkPath.AtName("port"), diags)
+
+	for name, value := range map[string]types.Int64{
+		"interval_seconds":    check.IntervalSeconds,
+		"timeout_seconds":     check.TimeoutSeconds,
+		"healthy_threshold":   check.HealthyThreshold,
+		"unhealthy_threshold": check.UnhealthyThreshold,
+	} {
+		limit := int64(maxHealthCheckThreshold)
+		if strings.HasSuffix(name, "_seconds") {
+			limit = maxHealthCheckInterval
+		}
+		if !value.IsNull() && !value.IsUnknown() && (value.ValueInt64() < 1 || value.ValueInt64() > limit) {
+			diags.AddAttributeError(
+				checkPath.AtName(name),
+				"Invalid Health Check",
+				fmt.Sprintf("The %s must be between 1 and %d, got: %d.", name, limit, value.ValueInt64()),
+			)
+		}
+	}
+	interval, timeout := check.IntervalSeconds, check.TimeoutSeconds
+	if interval.IsNull() {
+		interval = types.Int64Value(defaultHealthCheckInterval)
+	}
+	if timeout.IsNull() {
+		timeout = types.Int64Value(defaultHealthCheckTimeout)
+	}
+	if !interval.IsUnknown() && !timeout.IsUnknown() && timeout.ValueInt64() >= interval.ValueInt64() {
+		diags.AddAttributeError(
+			checkPath.AtName("timeout_seconds"),
+			"Invalid Health Check",
+			fmt.Sprintf("The timeout_seconds (%d) must be shorter than the interval_seconds (%d).", timeout.ValueInt64(), interval.ValueInt64()),
+		)
+	}
+}
+
+// validatePort checks that a configured port is a valid TCP port.
+func validatePort(port types.Int64, attributePath path.Path, diags *diag.Diagnostics) {
+	if !port.IsNull() && !port.IsUnknown() && (port.ValueInt64() < 1 || port.ValueInt64() > 65535) {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Port",
+			fmt.Sprintf("The port must be between 1 and 65535, got: %d.", port.ValueInt64()),
+		)
+	}
+}
+
+// validateMountPaths checks that the mount paths are clean absolute paths
+// outside the reserved paths, and that no mount hides another one.
+func validateMountPaths(mounts []functionMountModel, diags *diag.Diagnostics) {
+	var mountPaths []string
+	for i, mount := range mounts {
+		if !knownString(mount.MountPath) {
+			continue
+		}
+		mountPath := mount.MountPath.ValueString()
+		attributePath := path.Root("mounts").AtListIndex(i).AtName("mount_path")
+		if !strings.HasPrefix(mountPath, "/") || pathpkg.Clean(mountPath) != mountPath || mountPath == "/" {
+			diags.AddAttributeError(
+				attributePath,
+				"Invalid Mount Path",
+				"The mount_path must be a clean absolute path other than /, got: "+mountPath,
+			)
+			continue
+		}
+		for _, reserved := range reservedMountPaths {
+			if pathContains(reserved, mountPath) || pathContains(mountPath, reserved) {
+				diags.AddAttributeError(
+					attributePath,
+					"Invalid Mount Path",
+					fmt.Sprintf("The mount_path %s conflicts with %s, which is managed by the platform.", mountPath, reserved),
+				)
+			}
+		}
+		for _, other := range mountPaths {
+			if pathContains(other, mountPath) || pathContains(mountPath, other) {
+				diags.AddAttributeError(
+					attributePath,
+					"Conflicting Mount Paths",
+					fmt.Sprintf("The mount_path %s conflicts with the mount at %s. Mount paths must not be equal or nested.", mountPath, other),
+				)
+			}
+		}
+		mountPaths = append(mountPaths, mountPath)
+	}
+}
+
+// pathContains reports whether the clean absolute path child is dir or is
+// within dir.
+func pathContains(dir string, child string) bool {
+	return child == dir || strings.HasPrefix(child, dir+"/")
+}
+
+// validateMounts checks that the volumes the function mounts are replicated
+// to the locations of the provider, and that read-only volumes are mounted
+// read-only. Volumes created in the same apply are checked once the function
+// is planned again during the apply.
+func (r *functionResource) validateMounts(ctx context.Context, mounts []functionMountModel, diags *diag.Diagnostics) {
+	if r.client == nil {
+		return
+	}
+	for i, mount := range mounts {
+		if !knownString(mount.VolumeId) {
+			continue
+		}
+		attributePath := path.Root("mounts").AtListIndex(i)
+		volume, err := r.client.Volumes().Get(ctx, mount.VolumeId.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Error Reading Volume",
+				"Could not read volume ID "+mount.VolumeId.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		if volume == nil {
+			diags.AddAttributeError(
+				attributePath.AtName("volume_id"),
+				"Volume Not Found",
+				"There is no volume with ID "+mount.VolumeId.ValueString()+".",
+			)
+			continue
+		}
+		for _, location := range r.client.Locations {
+			if !slices.Contains(volume.Locations, location) {
+				diags.AddAttributeError(
+					attributePath.AtName("volume_id"),
+					"Incompatible Volume Location",
+					fmt.Sprintf("The function runs in %s, which the volume %s is not replicated to.", location, volume.Name),
+				)
+			}
+		}
+		if volume.AccessMode == coderforge.AccessReadOnlyMany && !mount.ReadOnly.IsUnknown() && !mount.ReadOnly.ValueBool() {
+			diags.AddAttributeError(
+				attributePath.AtName("read_only"),
+				"Read-Only Volume",
+				"The volume "+volume.Name+" has the access mode read_only_many, so it must be mounted with read_only = true.",
+			)
+		}
+	}
+}
+
+// validateNetwork checks that the network the function is attached to spans
+// the locations of the provider, and has the subnets the function names in
+// those locations. Networks created in the same apply are checked once the
+// function is planned again during the apply.
+func (r *functionResource) validateNetwork(ctx context.Context, network *functionNetworkModel, diags *diag.Diagnostics) {
+	if network == nil || !knownString(network.NetworkId) || network.Subnets.IsUnknown() || r.client == nil {
+		return
+	}
+	networkRes, err := r.client.Networks().Get(ctx, network.NetworkId.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Error Reading Network",
+			"Could not read network ID "+network.NetworkId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if networkRes == nil {
+		diags.AddAttributeError(
+			path.Root("network").AtName("network_id"),
+			"Network Not Found",
+			"There is no network with ID "+network.NetworkId.ValueString()+".",
+		)
+		return
+	}
+
+	for _, location := range r.client.Locations {
+		if !slices.Contains(networkRes.Locations, location) {
+			diags.AddAttributeError(
+				path.Root("network").AtName("network_id"),
+				"Incompatible Network Location",
+				fmt.Sprintf("The function runs in %s, which the network %s does not span. Add the location to the network or remove it from the provider locations.", location, networkRes.Name),
+			)
+		}
+	}
+	for _, name := range stringList(network.Subnets) {
+		i := slices.IndexFunc(networkRes.Subnets, func(subnet coderforge.Subnet) bool { return subnet.Name == name })
+		switch {
+		case i < 0:
+			diags.AddAttributeError(
+				path.Root("network").AtName("subnets"),
+				"Subnet Not Found",
+				fmt.Sprintf("The network %s has no subnet %s.", networkRes.Name, name),
+			)
+		case !slices.Contains(r.client.Locations, networkRes.Subnets[i].Location):
+			diags.AddAttributeError(
+				path.Root("network").AtName("subnets"),
+				"Incompatible Network Location",
+				fmt.Sprintf("The subnet %s is in %s, where the function does not run.", name, networkRes.Subnets[i].Location),
+			)
+		}
+	}
+}
+
+// ModifyPlan chooses the idempotency key of a planned create and keeps it in
+// private state until the create succeeds. It also plans a new version for
+// updates that publish.
+func (r *functionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan functionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.validateNetwork(ctx, plan.Network, &resp.Diagnostics)
+	r.validateMounts(ctx, plan.Mounts, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		if plan.Publish.ValueBool() && !req.Plan.Raw.Equal(req.State.Raw) {
+			diags = resp.Plan.SetAttribute(ctx, path.Root("version"), types.Int64Unknown())
+			resp.Diagnostics.Append(diags...)
+		}
+		return
+	}
+
+	// The key can only be derived once the whole configuration is known.
+	if !req.Config.Raw.IsFullyKnown() || r.client == nil {
+		return
+	}
+	key, err := r.client.IdempotencyKey(functionResourceItem(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error planning resource",
+			"Could not derive idempotency key: "+err.Error(),
+		)
+		return
+	}
+	diags = setIdempotencyKey(ctx, resp.Private, key)
+	resp.Diagnostics.Append(diags...)
+}
+
 // Create a new resource.
 func (r *functionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Retrieve values from plan
@@ -89,18 +620,37 @@ func (r *functionResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultFunctionCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
-	var resourceItem ResourceItem
-	resourceItem.Type = "function"
-	resourceItem.FunctionName = plan.FunctionName.ValueString()
-	code := Code{
-		PackageType: plan.Code.PackageType.ValueString(),
-		ImageUri:    plan.Code.ImageUri.ValueString(),
+	resourceItem := functionResourceItem(plan)
+
+	// Send the idempotency key chosen at plan time, or derive the same one,
+	// so a create retried after a timeout adopts the function the API
+	// already created instead of duplicating it.
+	key, diags := idempotencyKey(ctx, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	resourceItem.Code = code
-	resourceItem.Timeout = plan.Timeout.ValueInt64()
-	resourceItem.MaxRamSize = plan.MaxRamSize.ValueString()
-	resourceItemRes, err := r.client.CreateResource(ctx, resourceItem)
+	if key == "" {
+		var err error
+		key, err = r.client.IdempotencyKey(resourceItem)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating resource",
+				"Could not derive idempotency key: "+err.Error(),
+			)
+			return
+		}
+	}
+	resourceItemRes, err := r.createFunction(ctx, key, resourceItem)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating resource",
@@ -112,14 +662,14 @@ func (r *functionResource) Create(ctx context.Context, req resource.CreateReques
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(resourceItemRes.ID)
 	plan.FunctionName = types.StringValue(resourceItemRes.FunctionName)
-	if &resourceItemRes.Code != nil {
-		plan.Code = functionCodeModel{
-			PackageType: types.StringValue(resourceItemRes.Code.PackageType),
-			ImageUri:    types.StringValue(resourceItemRes.Code.ImageUri),
-		}
-	}
-	plan.Timeout = types.Int64Value(resourceItemRes.Timeout)
-	plan.MaxRamSize = types.StringValue(resourceItemRes.MaxRamSize)
+	plan.Code = newFunctionCodeModel(resourceItemRes.Code)
+	plan.Container = newFunctionContainerModel(resourceItemRes.Code)
+	plan.Timeout = int64ValueOrNull(resourceItemRes.Timeout)
+	plan.MaxRamSize = stringValueOrNull(resourceItemRes.MaxRamSize)
+	plan.Secrets = stringMapValueOrNull(resourceItemRes.Secrets)
+	plan.Network = newFunctionNetworkModel(resourceItemRes.Network)
+	plan.Mounts = newFunctionMountModels(resourceItemRes.Mounts)
+	plan.Version = int64ValueOrNull(resourceItemRes.PublishedVersion)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 	diags = resp.State.Set(ctx, plan)
@@ -127,6 +677,40 @@ func (r *functionResource) Create(ctx context.Context, req resource.CreateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// The function exists now, so the key has served its purpose.
+	diags = setIdempotencyKey(ctx, resp.Private, "")
+	resp.Diagnostics.Append(diags...)
+	diags = setVersion(ctx, resp.Private, resourceItemRes.Version)
+	resp.Diagnostics.Append(diags...)
+}
+
+// createFunction sends the create with the idempotency key. When the API
+// replays an earlier create whose function was deleted since, the create is
+// sent again under a key derived from the deleted function, so retries of it
+// adopt the new function instead of the deleted one.
+func (r *functionResource) createFunction(ctx context.Context, key string, resourceItem coderforge.ResourceItem) (*coderforge.ResourceItem, error) {
+	for {
+		createCtx := coderforge.ContextWithIdempotencyKey(ctx, key)
+		resourceItemRes, err := r.client.CreateResource(createCtx, resourceItem)
+		if err != nil || !coderforge.IdempotentReplayed(createCtx) {
+			return resourceItemRes, err
+		}
+		existing, err := r.client.GetResource(ctx, resourceItemRes.ID)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return resourceItemRes, nil
+		}
+		key, err = r.client.IdempotencyKey(struct {
+			Key       string `json:"key"`
+			DeletedID string `json:"deletedId"`
+		}{key, resourceItemRes.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
 }
 
 // Read resource information.
@@ -138,6 +722,14 @@ func (r *functionResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultFunctionReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed order value from HashiCups
 	resourceItemRes, err := r.client.GetResource(ctx, state.ID.ValueString())
 	if err != nil {
@@ -147,17 +739,32 @@ func (r *functionResource) Read(ctx context.Context, req resource.ReadRequest, r
 		)
 		return
 	}
+
+	// The function was deleted outside Terraform, plan to create it again.
+	if resourceItemRes == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	state.ID = types.StringValue(resourceItemRes.ID)
 	state.FunctionName = types.StringValue(resourceItemRes.FunctionName)
-	state.Code.PackageType = types.StringValue(resourceItemRes.Code.PackageType)
-	state.Code.ImageUri = types.StringValue(resourceItemRes.Code.ImageUri)
-	state.MaxRamSize = types.StringValue(resourceItemRes.MaxRamSize)
-	state.Timeout = types.Int64Value(resourceItemRes.Timeout)
+	state.Code = newFunctionCodeModel(resourceItemRes.Code)
+	state.Container = newFunctionContainerModel(resourceItemRes.Code)
+	state.MaxRamSize = stringValueOrNull(resourceItemRes.MaxRamSize)
+	state.Secrets = stringMapValueOrNull(resourceItemRes.Secrets)
+	state.Network = newFunctionNetworkModel(resourceItemRes.Network)
+	state.Mounts = newFunctionMountModels(resourceItemRes.Mounts)
+	state.Version = int64ValueOrNull(resourceItemRes.PublishedVersion)
+	state.Timeout = int64ValueOrNull(resourceItemRes.Timeout)
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Updates and deletes only succeed if nobody changed the function since.
+	diags = setVersion(ctx, resp.Private, resourceItemRes.Version)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -171,18 +778,25 @@ func (r *functionResource) Update(ctx context.Context, req resource.UpdateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	var resourceItem ResourceItem
-	resourceItem.Type = "function"
-	resourceItem.FunctionName = plan.FunctionName.ValueString()
-	code := Code{
-		PackageType: plan.Code.PackageType.ValueString(),
-		ImageUri:    plan.Code.ImageUri.ValueString(),
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultFunctionUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	resourceItem.Code = code
-	resourceItem.Timeout = plan.Timeout.ValueInt64()
-	resourceItem.MaxRamSize = plan.MaxRamSize.ValueString()
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+	resourceItem := functionResourceItem(plan)
 	resourceItem.ID = state.ID.ValueString()
-	resourceItemRes, err := r.client.UpdateResource(ctx, resourceItem)
+	version, diags := getVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resourceItemRes, err := r.client.UpdateResource(coderforge.ContextWithIfMatch(ctx, version), resourceItem)
+	if coderforge.IsConflict(err) {
+		addConflictError(&resp.Diagnostics, resourceItem.ID, err)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating order",
@@ -192,18 +806,20 @@ func (r *functionResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 	plan.ID = types.StringValue(resourceItemRes.ID)
 	plan.FunctionName = types.StringValue(resourceItemRes.FunctionName)
-	if &resourceItemRes.Code != nil {
-		plan.Code = functionCodeModel{
-			PackageType: types.StringValue(resourceItemRes.Code.PackageType),
-			ImageUri:    types.StringValue(resourceItemRes.Code.ImageUri),
-		}
-	}
-	plan.MaxRamSize = types.StringValue(resourceItemRes.MaxRamSize)
-	plan.Timeout = types.Int64Value(resourceItemRes.Timeout)
+	plan.Code = newFunctionCodeModel(resourceItemRes.Code)
+	plan.Container = newFunctionContainerModel(resourceItemRes.Code)
+	plan.MaxRamSize = stringValueOrNull(resourceItemRes.MaxRamSize)
+	plan.Secrets = stringMapValueOrNull(resourceItemRes.Secrets)
+	plan.Network = newFunctionNetworkModel(resourceItemRes.Network)
+	plan.Mounts = newFunctionMountModels(resourceItemRes.Mounts)
+	plan.Version = int64ValueOrNull(resourceItemRes.PublishedVersion)
+	plan.Timeout = int64ValueOrNull(resourceItemRes.Timeout)
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	resp.Diagnostics.Append(diagsState...)
+	diags = setVersion(ctx, resp.Private, resourceItemRes.Version)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -219,7 +835,24 @@ func (r *functionResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteResource(ctx, plan.ID.ValueString())
+	deleteTimeout, diags := plan.Timeouts.Delete(ctx, defaultFunctionDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	version, diags := getVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.DeleteResource(coderforge.ContextWithIfMatch(ctx, version), plan.ID.ValueString())
+	if coderforge.IsConflict(err) {
+		addConflictError(&resp.Diagnostics, plan.ID.ValueString(), err)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting resource",
@@ -229,6 +862,11 @@ func (r *functionResource) Delete(ctx context.Context, req resource.DeleteReques
 	return
 }
 
+// ImportState imports an existing function by its ID.
+func (r *functionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *functionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -237,12 +875,12 @@ func (r *functionResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*Client)
+	client, ok := req.ProviderData.(*coderforge.Client)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -250,3 +888,189 @@ func (r *functionResource) Configure(_ context.Context, req resource.ConfigureRe
 
 	r.client = client
 }
+
+// stringValueOrNull maps the empty strings the API returns for unset fields
+// to null, so optional attributes left out of the configuration stay null.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// int64ValueOrNull maps the zero values the API returns for unset fields to
+// null, so optional attributes left out of the configuration stay null.
+func int64ValueOrNull(value int64) types.Int64 {
+	if value == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
+// stringMapValueOrNull maps the empty maps the API returns for unset fields
+// to null.
+func stringMapValueOrNull(value map[string]string) types.Map {
+	if len(value) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	elements := make(map[string]attr.Value, len(value))
+	for k, v := range value {
+		elements[k] = types.StringValue(v)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+// stringMap returns the elements of a known map of strings.
+func stringMap(value types.Map) map[string]string {
+	if len(value.Elements()) == 0 {
+		return nil
+	}
+	elements := make(map[string]string, len(value.Elements()))
+	for k, v := range value.Elements() {
+		if v, ok := v.(types.String); ok {
+			elements[k] = v.ValueString()
+		}
+	}
+	return elements
+}
+
+// stringList returns the elements of a known list of strings.
+func stringList(value types.List) []string {
+	if len(value.Elements()) == 0 {
+		return nil
+	}
+	elements := make([]string, 0, len(value.Elements()))
+	for _, v := range value.Elements() {
+		if v, ok := v.(types.String); ok {
+			elements = append(elements, v.ValueString())
+		}
+	}
+	return elements
+}
+
+// newFunctionCodeModel returns the code attribute of a function.
+func newFunctionCodeModel(code coderforge.Code) *functionCodeModel {
+	return &functionCodeModel{
+		PackageType: types.StringValue(code.PackageType),
+		ImageUri:    stringValueOrNull(code.ImageUri),
+	}
+}
+
+// newFunctionContainerModel returns the container attribute of a function,
+// or nil if the code sets no container options.
+func newFunctionContainerModel(code coderforge.Code) *functionContainerModel {
+	if code.Command == nil && code.Args == nil && code.WorkingDir == "" && code.Port == 0 && code.HealthCheck == nil {
+		return nil
+	}
+	model := &functionContainerModel{
+		Command:    stringListValueOrNull(code.Command),
+		Args:       stringListValueOrNull(code.Args),
+		WorkingDir: stringValueOrNull(code.WorkingDir),
+		Port:       int64ValueOrNull(code.Port),
+	}
+	if check := code.HealthCheck; check != nil {
+		model.HealthCheck = &healthCheckModel{
+			Type:               types.StringValue(check.Type),
+			Path:               stringValueOrNull(check.Path),
+			Port:               int64ValueOrNull(check.Port),
+			IntervalSeconds:    types.Int64Value(check.IntervalSeconds),
+			TimeoutSeconds:     types.Int64Value(check.TimeoutSeconds),
+			HealthyThreshold:   types.Int64Value(check.HealthyThreshold),
+			UnhealthyThreshold: types.Int64Value(check.UnhealthyThreshold),
+		}
+	}
+	return model
+}
+
+// newFunctionNetworkModel returns the network attribute of a function.
+func newFunctionNetworkModel(network *coderforge.FunctionNetwork) *functionNetworkModel {
+	if network == nil {
+		return nil
+	}
+	subnets := stringListValueOrNull(network.Subnets)
+	egress := network.Egress
+	if egress == "" {
+		egress = coderforge.EgressAll
+	}
+	return &functionNetworkModel{
+		NetworkId: types.StringValue(network.NetworkId),
+		Subnets:   subnets,
+		Egress:    types.StringValue(egress),
+	}
+}
+
+// newFunctionMountModels returns the mounts attribute of a function.
+func newFunctionMountModels(mounts []coderforge.Mount) []functionMountModel {
+	if len(mounts) == 0 {
+		return nil
+	}
+	models := make([]functionMountModel, 0, len(mounts))
+	for _, mount := range mounts {
+		models = append(models, functionMountModel{
+			VolumeId:  types.StringValue(mount.VolumeId),
+			MountPath: types.StringValue(mount.MountPath),
+			ReadOnly:  types.BoolValue(mount.ReadOnly),
+		})
+	}
+	return models
+}
+
+// functionCode returns the API representation of the planned code and
+// container options.
+func functionCode(plan functionResourceModel) coderforge.Code {
+	code := coderforge.Code{
+		PackageType: plan.Code.PackageType.ValueString(),
+		ImageUri:    plan.Code.ImageUri.ValueString(),
+	}
+	container := plan.Container
+	if container == nil {
+		return code
+	}
+	code.Command = stringList(container.Command)
+	code.Args = stringList(container.Args)
+	code.WorkingDir = container.WorkingDir.ValueString()
+	code.Port = container.Port.ValueInt64()
+	if check := container.HealthCheck; check != nil {
+		code.HealthCheck = &coderforge.HealthCheck{
+			Type:               check.Type.ValueString(),
+			Path:               check.Path.ValueString(),
+			Port:               check.Port.ValueInt64(),
+			IntervalSeconds:    check.IntervalSeconds.ValueInt64(),
+			TimeoutSeconds:     check.TimeoutSeconds.ValueInt64(),
+			HealthyThreshold:   check.HealthyThreshold.ValueInt64(),
+			UnhealthyThreshold: check.UnhealthyThreshold.ValueInt64(),
+		}
+	}
+	return code
+}
+
+// functionResourceItem returns the API representation of a planned function.
+func functionResourceItem(plan functionResourceModel) coderforge.ResourceItem {
+	var network *coderforge.FunctionNetwork
+	if plan.Network != nil {
+		network = &coderforge.FunctionNetwork{
+			NetworkId: plan.Network.NetworkId.ValueString(),
+			Subnets:   stringList(plan.Network.Subnets),
+			Egress:    plan.Network.Egress.ValueString(),
+		}
+	}
+	var mounts []coderforge.Mount
+	for _, mount := range plan.Mounts {
+		mounts = append(mounts, coderforge.Mount{
+			VolumeId:  mount.VolumeId.ValueString(),
+			MountPath: mount.MountPath.ValueString(),
+			ReadOnly:  mount.ReadOnly.ValueBool(),
+		})
+	}
+	return coderforge.ResourceItem{
+		Type:         coderforge.ResourceTypeFunction,
+		FunctionName: plan.FunctionName.ValueString(),
+		Code:         functionCode(plan),
+		Timeout:      plan.Timeout.ValueInt64(),
+		MaxRamSize:   plan.MaxRamSize.ValueString(),
+		Secrets:      stringMap(plan.Secrets),
+		Network:      network,
+		Mounts:       mounts,
+		Publish:      plan.Publish.ValueBool(),
+	}
+}
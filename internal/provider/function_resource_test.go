@@ -0,0 +1,529 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-coderforge/internal/fakeserver"
+)
+
+// The function acceptance tests run against the fake API, so they use
+// resource.UnitTest and run in every `go test ./...` without TF_ACC.
+
+func TestAccFunctionResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_function.test", "id"),
+					resource.TestCheckResourceAttrSet("coderforge_function.test", "last_updated"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "function_name", "helloWorld"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "code.package_type", "container_image"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "code.image_uri", "docker.coderforge.org/hello:1"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "timeout", "180"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "max_ram_size", "512MB"),
+					testAccCheckItemField(server, "coderforge_function.test", "functionName", "helloWorld"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_function.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "timeouts"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:2", 300),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "code.image_uri", "docker.coderforge.org/hello:2"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "timeout", "300"),
+					testAccCheckItemField(server, "coderforge_function.test", "timeout", float64(300)),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccFunctionResource_minimal(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_function" "test" {
+  function_name = "minimal"
+  code = {
+    package_type = "javascript"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("coderforge_function.test", "code.image_uri"),
+					resource.TestCheckNoResourceAttr("coderforge_function.test", "timeout"),
+					resource.TestCheckNoResourceAttr("coderforge_function.test", "max_ram_size"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFunctionResource_drift(t *testing.T) {
+	server := testAccFakeServer(t)
+	config := testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180)
+
+	var id string
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCaptureID("coderforge_function.test", &id),
+			},
+			// A change made outside Terraform shows up in the plan...
+			{
+				PreConfig: func() {
+					if err := server.SetResourceField(id, "maxRamSize", "1GB"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// ...and is reverted by the next apply.
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "max_ram_size", "512MB"),
+					testAccCheckItemField(server, "coderforge_function.test", "maxRamSize", "512MB"),
+				),
+			},
+			// A function deleted outside Terraform is created again.
+			{
+				PreConfig: func() {
+					server.RemoveResource(id)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckItemField(server, "coderforge_function.test", "functionName", "helloWorld"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["coderforge_function.test"].Primary.ID == id {
+							return fmt.Errorf("expected a new function, got the deleted ID %s", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccFunctionResource_idempotentCreate(t *testing.T) {
+	server := testAccFakeServer(t)
+	config := testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			// Every attempt of the create is applied but times out...
+			{
+				PreConfig: func() {
+					server.FailNextCreates(3)
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`status: 504`),
+			},
+			// ...and the next apply adopts the function instead of
+			// creating a duplicate.
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckItemField(server, "coderforge_function.test", "functionName", "helloWorld"),
+					testAccCheckFunctionCount(server, 1),
+				),
+			},
+			// A create that times out once is retried within the apply.
+			{
+				PreConfig: func() {
+					server.FailNextCreates(1)
+				},
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+resource "coderforge_function" "other" {
+  function_name = "other"
+  code = {
+    package_type = "javascript"
+  }
+}
+`,
+				Check: testAccCheckFunctionCount(server, 2),
+			},
+		},
+	})
+}
+
+func TestAccFunctionResource_recreateAfterDestroy(t *testing.T) {
+	server := testAccFakeServer(t)
+	config := testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180)
+
+	var id string
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCaptureID("coderforge_function.test", &id),
+			},
+			{
+				Config: testAccProviderConfig(server),
+				Check:  testAccCheckFunctionCount(server, 0),
+			},
+			// The same create again must not adopt the deleted function.
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckItemField(server, "coderforge_function.test", "functionName", "helloWorld"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["coderforge_function.test"].Primary.ID == id {
+							return fmt.Errorf("expected a new function, got the deleted ID %s", id)
+						}
+						return nil
+					},
+					testAccCaptureID("coderforge_function.test", &id),
+				),
+			},
+			// Nor the one created in its place, after another destroy.
+			{
+				Config: testAccProviderConfig(server),
+				Check:  testAccCheckFunctionCount(server, 0),
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckFunctionCount(server, 1),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["coderforge_function.test"].Primary.ID == id {
+							return fmt.Errorf("expected a new function, got the deleted ID %s", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccFunctionResource_conflict(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var id string
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180),
+				Check:  testAccCaptureID("coderforge_function.test", &id),
+			},
+			// A change made after Terraform read the function fails the
+			// update instead of being overwritten...
+			{
+				PreConfig: func() {
+					server.ChangeBeforeNextWrite(id, "maxRamSize", "1GB")
+				},
+				Config:      testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:2", 180),
+				ExpectError: regexp.MustCompile(`changed outside Terraform`),
+			},
+			// ...until the next refresh has seen it.
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:2", 180),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "code.image_uri", "docker.coderforge.org/hello:2"),
+					testAccCheckItemField(server, "coderforge_function.test", "maxRamSize", "512MB"),
+				),
+			},
+			// Deletes are checked too.
+			{
+				PreConfig: func() {
+					server.ChangeBeforeNextWrite(id, "maxRamSize", "1GB")
+				},
+				Config:      testAccProviderConfig(server),
+				ExpectError: regexp.MustCompile(`changed outside Terraform`),
+			},
+			// The post-test destroy does not refresh, so refresh here.
+			{
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccFunctionResource_publish(t *testing.T) {
+	server := testAccFakeServer(t)
+	config := func(image string, publish bool) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/%s"
+  }
+  publish = %t
+}
+`, image, publish)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config("hello:1", false),
+				Check:  resource.TestCheckNoResourceAttr("coderforge_function.test", "version"),
+			},
+			{
+				Config: config("hello:1", true),
+				Check:  resource.TestCheckResourceAttr("coderforge_function.test", "version", "1"),
+			},
+			{
+				Config: config("hello:2", true),
+				Check:  resource.TestCheckResourceAttr("coderforge_function.test", "version", "2"),
+			},
+			// Updates that do not publish keep the latest version.
+			{
+				Config: config("hello:3", false),
+				Check:  resource.TestCheckResourceAttr("coderforge_function.test", "version", "2"),
+			},
+		},
+	})
+}
+
+func TestAccFunctionResource_container(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	config := func(healthCheck string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/hello:1"
+  }
+  container = {
+    command      = ["/usr/bin/tini", "--"]
+    args         = ["hello", "--listen", ":8080"]
+    working_dir  = "/srv"
+    port         = 8080
+    health_check = %s
+  }
+}
+`, healthCheck)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(`{
+      type = "http"
+      path = "/healthz"
+    }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.command.#", "2"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.args.2", ":8080"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.working_dir", "/srv"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.port", "8080"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.path", "/healthz"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.interval_seconds", "10"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.timeout_seconds", "5"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.healthy_threshold", "1"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.unhealthy_threshold", "3"),
+					resource.TestCheckNoResourceAttr("coderforge_function.test", "container.health_check.port"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_function.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: config(`{
+      type                = "tcp"
+      port                = 9090
+      interval_seconds    = 30
+      unhealthy_threshold = 5
+    }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.type", "tcp"),
+					resource.TestCheckNoResourceAttr("coderforge_function.test", "container.health_check.path"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.port", "9090"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.interval_seconds", "30"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "container.health_check.unhealthy_threshold", "5"),
+				),
+			},
+			// Removing the health check falls back to the image.
+			{
+				Config: config(`null`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("coderforge_function.test", "container.health_check.type"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFunctionResource_containerValidation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		packageType string
+		container   string
+		err         string
+	}{
+		"not a container":    {"wasm", `args = ["x"]`, `Invalid Container Options`},
+		"relative dir":       {"container_image", `working_dir = "srv"`, `Invalid Working Directory`},
+		"bad port":           {"container_image", `port = 70000`, `Invalid Port`},
+		"bad check type":     {"container_image", `health_check = { type = "grpc" }`, `Invalid Health Check Type`},
+		"http without path":  {"container_image", `health_check = { type = "http" }`, `Missing Health Check Path`},
+		"tcp with path":      {"container_image", `health_check = { type = "tcp", path = "/" }`, `TCP health checks`},
+		"relative path":      {"container_image", `health_check = { type = "http", path = "healthz" }`, `must start with /`},
+		"bad threshold":      {"container_image", `health_check = { type = "tcp", healthy_threshold = 0 }`, `healthy_threshold must be between 1 and 10`},
+		"timeout > interval": {"container_image", `health_check = { type = "tcp", interval_seconds = 5 }`, `must be shorter than the interval_seconds`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = %q
+  }
+  container = {
+    %s
+  }
+}
+`, tc.packageType, tc.container),
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func testAccFunctionResourceConfig(image string, timeout int) string {
+	return fmt.Sprintf(`
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/%[1]s"
+  }
+  timeout      = %[2]d
+  max_ram_size = "512MB"
+}
+`, image, timeout)
+}
+
+// testAccCaptureID stores the ID of the named resource in id.
+func testAccCaptureID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccCheckItemField checks a field of the item the fake API stores for
+// the named resource, whatever its type.
+func testAccCheckItemField(server *fakeserver.Server, name string, field string, expected any) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		item, ok := server.Resource(rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("resource %s not found in the API", rs.Primary.ID)
+		}
+		if item[field] != expected {
+			return fmt.Errorf("expected %s to be %v, got %v", field, expected, item[field])
+		}
+		return nil
+	}
+}
+
+// testAccCheckFunctionCount checks how many functions the fake API holds.
+func testAccCheckFunctionCount(server *fakeserver.Server, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var n int
+		for _, item := range server.Resources() {
+			if item["type"] == "function" {
+				n++
+			}
+		}
+		if n != expected {
+			return fmt.Errorf("expected %d functions, got %d", expected, n)
+		}
+		return nil
+	}
+}
+
+// testAccCheckFunctionDestroy verifies the API holds no functions once the
+// test case has destroyed its resources.
+func testAccCheckFunctionDestroy(server *fakeserver.Server) resource.TestCheckFunc {
+	return testAccCheckDestroy(server, "function")
+}
+
+// testAccCheckDestroy verifies the API holds no items of the given types
+// once the test case has destroyed its resources.
+func testAccCheckDestroy(server *fakeserver.Server, itemTypes ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, item := range server.Resources() {
+			if itemType, _ := item["type"].(string); slices.Contains(itemTypes, itemType) {
+				return fmt.Errorf("%s %s still exists", strings.ReplaceAll(itemType, "_", " "), item.ID())
+			}
+		}
+		return nil
+	}
+}
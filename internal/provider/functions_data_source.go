@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ datasource.DataSource              = &functionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &functionsDataSource{}
+)
+
+func NewFunctionsDataSource() datasource.DataSource {
+	return &functionsDataSource{}
+}
+
+type functionsDataSourceModel struct {
+	StackId    types.String         `tfsdk:"stack_id"`
+	NamePrefix types.String         `tfsdk:"name_prefix"`
+	Location   types.String         `tfsdk:"location"`
+	Functions  []functionsDataModel `tfsdk:"functions"`
+}
+
+type functionsDataModel struct {
+	ID           types.String       `tfsdk:"id"`
+	FunctionName types.String       `tfsdk:"function_name"`
+	Code         *functionCodeModel `tfsdk:"code"`
+	Timeout      types.Int64        `tfsdk:"timeout"`
+	MaxRamSize   types.String       `tfsdk:"max_ram_size"`
+}
+
+type functionsDataSource struct {
+	client *coderforge.Client
+}
+
+func (d *functionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_functions"
+}
+
+func (d *functionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"stack_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional: true,
+			},
+			"location": schema.StringAttribute{
+				Optional: true,
+			},
+			"functions": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"function_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"code": schema.SingleNestedAttribute{
+							Computed: true,
+							Attributes: map[string]schema.Attribute{
+								"package_type": schema.StringAttribute{
+									Computed: true,
+								},
+								"image_uri": schema.StringAttribute{
+									Computed: true,
+								},
+							},
+						},
+						"timeout": schema.Int64Attribute{
+							Computed: true,
+						},
+						"max_ram_size": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read lists every function in the cloud space that matches the filters.
+func (d *functionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state functionsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	functions, err := d.client.Functions().List(ctx, coderforge.ListFilter{
+		StackId:    state.StackId.ValueString(),
+		NamePrefix: state.NamePrefix.ValueString(),
+		Location:   state.Location.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Functions",
+			"Could not list functions in cloud space "+d.client.CloudSpace+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Functions = []functionsDataModel{}
+	for _, function := range functions {
+		state.Functions = append(state.Functions, functionsDataModel{
+			ID:           types.StringValue(function.ID),
+			FunctionName: types.StringValue(function.FunctionName),
+			Code: &functionCodeModel{
+				PackageType: types.StringValue(function.Code.PackageType),
+				ImageUri:    stringValueOrNull(function.Code.ImageUri),
+			},
+			Timeout:    int64ValueOrNull(function.Timeout),
+			MaxRamSize: stringValueOrNull(function.MaxRamSize),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *functionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccFunctionsDataSource(t *testing.T) {
+	server := testAccFakeServer(t)
+	server.PageSize = 1
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_function" "api" {
+  function_name = "api"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/api:1"
+  }
+  timeout = 60
+}
+
+resource "coderforge_function" "worker" {
+  function_name = "worker"
+  code = {
+    package_type = "javascript"
+  }
+}
+
+data "coderforge_functions" "all" {
+  depends_on = [coderforge_function.api, coderforge_function.worker]
+}
+
+data "coderforge_functions" "api" {
+  name_prefix = "ap"
+  depends_on  = [coderforge_function.api, coderforge_function.worker]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderforge_functions.all", "functions.#", "2"),
+					resource.TestCheckResourceAttr("data.coderforge_functions.api", "functions.#", "1"),
+					resource.TestCheckResourceAttrPair("data.coderforge_functions.api", "functions.0.id", "coderforge_function.api", "id"),
+					resource.TestCheckResourceAttr("data.coderforge_functions.api", "functions.0.function_name", "api"),
+					resource.TestCheckResourceAttr("data.coderforge_functions.api", "functions.0.code.image_uri", "docker.coderforge.org/api:1"),
+					resource.TestCheckResourceAttr("data.coderforge_functions.api", "functions.0.timeout", "60"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringListValueOrNull maps the empty lists the API returns for unset
+// fields to null, so optional attributes left out of the configuration stay
+// null.
+func stringListValueOrNull(value []string) types.List {
+	if len(value) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	elements := make([]attr.Value, 0, len(value))
+	for _, v := range value {
+		elements = append(elements, types.StringValue(v))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
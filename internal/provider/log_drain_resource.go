@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &logDrainResource{}
+	_ resource.ResourceWithConfigure      = &logDrainResource{}
+	_ resource.ResourceWithImportState    = &logDrainResource{}
+	_ resource.ResourceWithValidateConfig = &logDrainResource{}
+)
+
+// logDrainSchemes are the endpoint URL schemes each protocol accepts.
+var logDrainSchemes = map[string][]string{
+	coderforge.LogDrainSyslog: {"syslog", "syslog+tcp", "syslog+tls"},
+	coderforge.LogDrainHTTP:   {"http", "https"},
+	coderforge.LogDrainOTLP:   {"http", "https", "grpc", "grpcs"},
+}
+
+// logLevels are the log levels, from the least to the most severe.
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+func NewLogDrainResource() resource.Resource {
+	return &logDrainResource{}
+}
+
+type logDrainResourceModel struct {
+	ID          types.String    `tfsdk:"id"`
+	Name        types.String    `tfsdk:"name"`
+	Protocol    types.String    `tfsdk:"protocol"`
+	Endpoint    types.String    `tfsdk:"endpoint"`
+	Headers     types.Map       `tfsdk:"headers"`
+	Filter      *logFilterModel `tfsdk:"filter"`
+	Enabled     types.Bool      `tfsdk:"enabled"`
+	LastUpdated types.String    `tfsdk:"last_updated"`
+}
+
+type logFilterModel struct {
+	FunctionIds types.List   `tfsdk:"function_ids"`
+	MinLevel    types.String `tfsdk:"min_level"`
+	Pattern     types.String `tfsdk:"pattern"`
+}
+
+type logDrainResource struct {
+	client *coderforge.Client
+}
+
+func (r *logDrainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_log_drain"
+}
+
+func (r *logDrainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"protocol": schema.StringAttribute{
+				Required: true,
+			},
+			"endpoint": schema.StringAttribute{
+				Required: true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"filter": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"function_ids": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"min_level": schema.StringAttribute{
+						Optional: true,
+					},
+					"pattern": schema.StringAttribute{
+						Optional: true,
+					},
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the endpoint against the protocol, and the filter.
+func (r *logDrainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config logDrainResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if knownString(config.Protocol) {
+		protocol := config.Protocol.ValueString()
+		schemes, ok := logDrainSchemes[protocol]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("protocol"),
+				"Invalid Log Drain Protocol",
+				"The protocol must be one of syslog, http or otlp, got: "+protocol,
+			)
+			return
+		}
+		if knownString(config.Endpoint) {
+			endpoint, err := url.Parse(config.Endpoint.ValueString())
+			if err != nil || endpoint.Host == "" || !slices.Contains(schemes, endpoint.Scheme) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("endpoint"),
+					"Invalid Log Drain Endpoint",
+					fmt.Sprintf("A %s endpoint must be a URL with a host and one of the schemes %s, got: %s", protocol, strings.Join(schemes, ", "), config.Endpoint.ValueString()),
+				)
+			}
+		}
+		if protocol == coderforge.LogDrainSyslog && !config.Headers.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("headers"),
+				"Unexpected Log Drain Attribute",
+				"A syslog log drain does not support headers.",
+			)
+		}
+	}
+
+	if config.Filter == nil {
+		return
+	}
+	if minLevel := config.Filter.MinLevel; knownString(minLevel) && !slices.Contains(logLevels, minLevel.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("filter").AtName("min_level"),
+			"Invalid Log Filter",
+			"The min_level must be one of debug, info, warn or error, got: "+minLevel.ValueString(),
+		)
+	}
+	if pattern := config.Filter.Pattern; knownString(pattern) {
+		if _, err := regexp.Compile(pattern.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("filter").AtName("pattern"),
+				"Invalid Log Filter",
+				"The pattern must be an RE2 regular expression: "+err.Error(),
+			)
+		}
+	}
+}
+
+func (r *logDrainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan logDrainResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := logDrainItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	logDrain, err := r.client.LogDrains().Create(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating log drain",
+			"Could not create log drain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	headers := plan.Headers
+	plan, diags = newLogDrainModel(ctx, logDrain)
+	resp.Diagnostics.Append(diags...)
+	plan.Headers = headers
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *logDrainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state logDrainResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logDrain, err := r.client.LogDrains().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Log Drain",
+			"Could not read log drain ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The log drain was deleted outside Terraform, plan to create it again.
+	if logDrain == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The API never returns the headers, so keep the ones in state.
+	headers, lastUpdated := state.Headers, state.LastUpdated
+	state, diags = newLogDrainModel(ctx, logDrain)
+	resp.Diagnostics.Append(diags...)
+	state.Headers, state.LastUpdated = headers, lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *logDrainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan logDrainResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state logDrainResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := logDrainItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	item.ID = state.ID.ValueString()
+	logDrain, err := r.client.LogDrains().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating log drain",
+			"Could not update log drain, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	headers := plan.Headers
+	plan, diags = newLogDrainModel(ctx, logDrain)
+	resp.Diagnostics.Append(diags...)
+	plan.Headers = headers
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *logDrainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state logDrainResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.LogDrains().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting log drain",
+			"Could not delete log drain, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *logDrainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *logDrainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func logDrainItem(ctx context.Context, plan logDrainResourceModel) (coderforge.LogDrain, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	logDrain := coderforge.LogDrain{
+		Name:     plan.Name.ValueString(),
+		Protocol: plan.Protocol.ValueString(),
+		Endpoint: plan.Endpoint.ValueString(),
+		Enabled:  plan.Enabled.ValueBool(),
+	}
+	if !plan.Headers.IsNull() {
+		diags.Append(plan.Headers.ElementsAs(ctx, &logDrain.Headers, false)...)
+	}
+	if plan.Filter != nil {
+		logDrain.Filter = &coderforge.LogFilter{
+			MinLevel: plan.Filter.MinLevel.ValueString(),
+			Pattern:  plan.Filter.Pattern.ValueString(),
+		}
+		if !plan.Filter.FunctionIds.IsNull() {
+			diags.Append(plan.Filter.FunctionIds.ElementsAs(ctx, &logDrain.Filter.FunctionIds, false)...)
+		}
+	}
+	return logDrain, diags
+}
+
+// newLogDrainModel maps a log drain to the resource model. The headers are
+// left null, as the API never returns them.
+func newLogDrainModel(ctx context.Context, logDrain *coderforge.LogDrain) (logDrainResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	model := logDrainResourceModel{
+		ID:       types.StringValue(logDrain.ID),
+		Name:     types.StringValue(logDrain.Name),
+		Protocol: types.StringValue(logDrain.Protocol),
+		Endpoint: types.StringValue(logDrain.Endpoint),
+		Headers:  types.MapNull(types.StringType),
+		Enabled:  types.BoolValue(logDrain.Enabled),
+	}
+	if logDrain.Filter != nil {
+		functionIds := types.ListNull(types.StringType)
+		if len(logDrain.Filter.FunctionIds) > 0 {
+			var d diag.Diagnostics
+			functionIds, d = types.ListValueFrom(ctx, types.StringType, logDrain.Filter.FunctionIds)
+			diags.Append(d...)
+		}
+		model.Filter = &logFilterModel{
+			FunctionIds: functionIds,
+			MinLevel:    stringValueOrNull(logDrain.Filter.MinLevel),
+			Pattern:     stringValueOrNull(logDrain.Filter.Pattern),
+		}
+	}
+	return model, diags
+}
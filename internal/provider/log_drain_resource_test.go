@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccLogDrainResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "log_drain"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+resource "coderforge_log_drain" "errors" {
+  name     = "errors"
+  protocol = "http"
+  endpoint = "https://logs.example.com/ingest"
+
+  headers = {
+    Authorization = "Bearer secret"
+  }
+
+  filter = {
+    function_ids = [coderforge_function.test.id]
+    min_level    = "warn"
+    pattern      = "timeout|refused"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_log_drain.errors", "id"),
+					resource.TestCheckResourceAttr("coderforge_log_drain.errors", "headers.Authorization", "Bearer secret"),
+					resource.TestCheckResourceAttr("coderforge_log_drain.errors", "filter.function_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("coderforge_log_drain.errors", "filter.function_ids.0", "coderforge_function.test", "id"),
+					resource.TestCheckResourceAttr("coderforge_log_drain.errors", "filter.min_level", "warn"),
+					resource.TestCheckResourceAttr("coderforge_log_drain.errors", "enabled", "true"),
+					testAccCheckItemField(server, "coderforge_log_drain.errors", "type", "log_drain"),
+				),
+			},
+			// The headers are not returned by the API, so they cannot be
+			// imported.
+			{
+				ResourceName:            "coderforge_log_drain.errors",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "headers"},
+			},
+			// Switch to syslog without a filter.
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+resource "coderforge_log_drain" "errors" {
+  name     = "errors"
+  protocol = "syslog"
+  endpoint = "syslog+tls://logs.example.com:6514"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_log_drain.errors", "protocol", "syslog"),
+					resource.TestCheckNoResourceAttr("coderforge_log_drain.errors", "headers.%"),
+					resource.TestCheckNoResourceAttr("coderforge_log_drain.errors", "filter.min_level"),
+					testAccCheckItemField(server, "coderforge_log_drain.errors", "endpoint", "syslog+tls://logs.example.com:6514"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLogDrainResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		logDrain string
+		err      string
+	}{
+		"unknown protocol":  {"protocol = \"kafka\"\n  endpoint = \"kafka://broker:9092\"", `Invalid Log Drain Protocol`},
+		"syslog over http":  {"protocol = \"syslog\"\n  endpoint = \"https://logs.example.com\"", `Invalid Log Drain Endpoint`},
+		"http without host": {"protocol = \"http\"\n  endpoint = \"https:///ingest\"", `Invalid Log Drain Endpoint`},
+		"syslog headers":    {"protocol = \"syslog\"\n  endpoint = \"syslog://logs.example.com:514\"\n  headers = { a = \"b\" }", `does not support headers`},
+		"bad level":         {"protocol = \"otlp\"\n  endpoint = \"grpcs://otel.example.com:4317\"\n  filter = { min_level = \"fatal\" }", `Invalid Log Filter`},
+		"bad pattern":       {"protocol = \"otlp\"\n  endpoint = \"grpcs://otel.example.com:4317\"\n  filter = { pattern = \"(\" }", `Invalid Log Filter`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_log_drain" "test" {
+  name = "test"
+  ` + tc.logDrain + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
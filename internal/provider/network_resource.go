@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &networkResource{}
+	_ resource.ResourceWithConfigure      = &networkResource{}
+	_ resource.ResourceWithImportState    = &networkResource{}
+	_ resource.ResourceWithValidateConfig = &networkResource{}
+)
+
+func NewNetworkResource() resource.Resource {
+	return &networkResource{}
+}
+
+type networkResourceModel struct {
+	ID          types.String  `tfsdk:"id"`
+	Name        types.String  `tfsdk:"name"`
+	CidrBlock   types.String  `tfsdk:"cidr_block"`
+	Locations   types.List    `tfsdk:"locations"`
+	Subnets     []subnetModel `tfsdk:"subnets"`
+	LastUpdated types.String  `tfsdk:"last_updated"`
+}
+
+type subnetModel struct {
+	Name      types.String `tfsdk:"name"`
+	CidrBlock types.String `tfsdk:"cidr_block"`
+	Location  types.String `tfsdk:"location"`
+}
+
+type networkResource struct {
+	client *coderforge.Client
+}
+
+func (r *networkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_network"
+}
+
+func (r *networkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cidr_block": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"locations": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"subnets": schema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required: true,
+						},
+						"cidr_block": schema.StringAttribute{
+							Required: true,
+						},
+						"location": schema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the subnets are unique ranges of the network
+// in its locations.
+func (r *networkResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config networkResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var network netip.Prefix
+	if knownString(config.CidrBlock) {
+		var err error
+		network, err = netip.ParsePrefix(config.CidrBlock.ValueString())
+		if err != nil || !network.Addr().Is4() || !network.Addr().IsPrivate() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cidr_block"),
+				"Invalid CIDR Block",
+				"The cidr_block must be a private IPv4 range such as 10.0.0.0/16, got: "+config.CidrBlock.ValueString(),
+			)
+		}
+	}
+
+	var locations []string
+	if !config.Locations.IsUnknown() {
+		diags = config.Locations.ElementsAs(ctx, &locations, true)
+		resp.Diagnostics.Append(diags...)
+		if len(config.Locations.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("locations"),
+				"Missing Network Location",
+				"A network must span at least one location.",
+			)
+		}
+	}
+
+	names := map[string]bool{}
+	var ranges []netip.Prefix
+	for i, subnet := range config.Subnets {
+		subnetPath := path.Root("subnets").AtListIndex(i)
+		if knownString(subnet.Name) {
+			if names[subnet.Name.ValueString()] {
+				resp.Diagnostics.AddAttributeError(
+					subnetPath.AtName("name"),
+					"Duplicate Subnet",
+					"The subnet name "+subnet.Name.ValueString()+" is used more than once.",
+				)
+			}
+			names[subnet.Name.ValueString()] = true
+		}
+		if knownString(subnet.Location) && !config.Locations.IsUnknown() && !slices.Contains(locations, subnet.Location.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				subnetPath.AtName("location"),
+				"Invalid Subnet Location",
+				"The location of a subnet must be one of the locations of the network, got: "+subnet.Location.ValueString(),
+			)
+		}
+		if !knownString(subnet.CidrBlock) {
+			continue
+		}
+		subnetRange, err := netip.ParsePrefix(subnet.CidrBlock.ValueString())
+		switch {
+		case err != nil:
+			resp.Diagnostics.AddAttributeError(
+				subnetPath.AtName("cidr_block"),
+				"Invalid CIDR Block",
+				"The cidr_block of a subnet must be an IPv4 range, got: "+subnet.CidrBlock.ValueString(),
+			)
+			continue
+		case network.IsValid() && (subnetRange.Bits() < network.Bits() || !network.Contains(subnetRange.Addr())):
+			resp.Diagnostics.AddAttributeError(
+				subnetPath.AtName("cidr_block"),
+				"Invalid CIDR Block",
+				"The subnet "+subnetRange.String()+" is not within the network "+network.String()+".",
+			)
+		}
+		for _, other := range ranges {
+			if other.Overlaps(subnetRange) {
+				resp.Diagnostics.AddAttributeError(
+					subnetPath.AtName("cidr_block"),
+					"Overlapping Subnets",
+					"The subnet "+subnetRange.String()+" overlaps "+other.String()+".",
+				)
+			}
+		}
+		ranges = append(ranges, subnetRange)
+	}
+}
+
+func (r *networkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan networkResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := networkItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	network, err := r.client.Networks().Create(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating network",
+			"Could not create network, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newNetworkModel(ctx, network)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *networkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state networkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	network, err := r.client.Networks().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Network",
+			"Could not read network ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The network was deleted outside Terraform, plan to create it again.
+	if network == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state, diags = newNetworkModel(ctx, network)
+	resp.Diagnostics.Append(diags...)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *networkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan networkResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state networkResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := networkItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	item.ID = state.ID.ValueString()
+	network, err := r.client.Networks().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating network",
+			"Could not update network, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newNetworkModel(ctx, network)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *networkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state networkResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Networks().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting network",
+			"Could not delete network, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *networkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *networkResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func networkItem(ctx context.Context, plan networkResourceModel) (coderforge.Network, diag.Diagnostics) {
+	network := coderforge.Network{
+		Name:      plan.Name.ValueString(),
+		CidrBlock: plan.CidrBlock.ValueString(),
+	}
+	diags := plan.Locations.ElementsAs(ctx, &network.Locations, false)
+	for _, subnet := range plan.Subnets {
+		network.Subnets = append(network.Subnets, coderforge.Subnet{
+			Name:      subnet.Name.ValueString(),
+			CidrBlock: subnet.CidrBlock.ValueString(),
+			Location:  subnet.Location.ValueString(),
+		})
+	}
+	return network, diags
+}
+
+func newNetworkModel(ctx context.Context, network *coderforge.Network) (networkResourceModel, diag.Diagnostics) {
+	locations, diags := types.ListValueFrom(ctx, types.StringType, network.Locations)
+	model := networkResourceModel{
+		ID:        types.StringValue(network.ID),
+		Name:      types.StringValue(network.Name),
+		CidrBlock: types.StringValue(network.CidrBlock),
+		Locations: locations,
+	}
+	for _, subnet := range network.Subnets {
+		model.Subnets = append(model.Subnets, subnetModel{
+			Name:      types.StringValue(subnet.Name),
+			CidrBlock: types.StringValue(subnet.CidrBlock),
+			Location:  types.StringValue(subnet.Location),
+		})
+	}
+	return model, diags
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNetworkResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	network := func(locations string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_network" "private" {
+  name       = "private"
+  cidr_block = "10.0.0.0/16"
+  locations  = %s
+  subnets = [
+    { name = "gbr-1-a", cidr_block = "10.0.1.0/24", location = "gbr-1" },
+    { name = "gbr-2-a", cidr_block = "10.0.2.0/24", location = "gbr-2" },
+  ]
+}
+`, locations)
+	}
+	function := func(network string) string {
+		return fmt.Sprintf(`
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/hello:1"
+  }
+  network = %s
+}
+`, network)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "network"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: network(`["gbr-1", "gbr-2"]`) + function(`{
+    network_id = coderforge_network.private.id
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_network.private", "id"),
+					resource.TestCheckResourceAttr("coderforge_network.private", "subnets.#", "2"),
+					resource.TestCheckResourceAttrPair("coderforge_function.test", "network.network_id", "coderforge_network.private", "id"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "network.egress", "all"),
+					resource.TestCheckNoResourceAttr("coderforge_function.test", "network.subnets"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_network.private",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				ResourceName:            "coderforge_function.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// The egress and subnets of the function are changed in place.
+			{
+				Config: network(`["gbr-1", "gbr-2"]`) + function(`{
+    network_id = coderforge_network.private.id
+    subnets    = ["gbr-1-a", "gbr-2-a"]
+    egress     = "private_only"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_function.test", "network.egress", "private_only"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "network.subnets.#", "2"),
+				),
+			},
+			// Detaching the function from the network.
+			{
+				Config: network(`["gbr-1", "gbr-2"]`) + function(`null`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("coderforge_function.test", "network.network_id"),
+					testAccCheckItemField(server, "coderforge_function.test", "network", nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkResource_incompatibleLocation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	network := testAccProviderConfig(server) + `
+resource "coderforge_network" "private" {
+  name       = "private"
+  cidr_block = "10.0.0.0/16"
+  locations  = ["gbr-1", "fra-1"]
+  subnets = [
+    { name = "gbr-1-a", cidr_block = "10.0.1.0/24", location = "gbr-1" },
+    { name = "fra-1-a", cidr_block = "10.0.2.0/24", location = "fra-1" },
+  ]
+}
+`
+	function := func(subnets string) string {
+		return fmt.Sprintf(`
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+  }
+  network = {
+    network_id = coderforge_network.private.id
+    subnets    = %s
+  }
+}
+`, subnets)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "network"),
+		Steps: []resource.TestStep{
+			// A network created in the same apply is checked when the
+			// function is planned again during the apply.
+			{
+				Config:      network + function(`null`),
+				ExpectError: regexp.MustCompile(`Incompatible Network Location`),
+			},
+			// Existing networks are checked at plan time.
+			{
+				Config: network,
+			},
+			{
+				Config:      network + function(`null`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Incompatible Network Location`),
+			},
+			{
+				Config:      network + function(`["fra-1-a", "gbr-1-b"]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`(?s)Incompatible Network Location.*Subnet Not Found`),
+			},
+		},
+	})
+}
+
+func TestAccNetworkResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		cidrBlock string
+		locations string
+		subnets   string
+		err       string
+	}{
+		"public range":      {"8.8.0.0/16", `["gbr-1"]`, `[]`, `Invalid CIDR Block`},
+		"bad range":         {"10.0.0.0", `["gbr-1"]`, `[]`, `Invalid CIDR Block`},
+		"no locations":      {"10.0.0.0/16", `[]`, `[]`, `Missing Network Location`},
+		"subnet outside":    {"10.0.0.0/16", `["gbr-1"]`, `[{ name = "a", cidr_block = "10.1.0.0/24", location = "gbr-1" }]`, `not within the network`},
+		"subnet location":   {"10.0.0.0/16", `["gbr-1"]`, `[{ name = "a", cidr_block = "10.0.0.0/24", location = "fra-1" }]`, `Invalid Subnet Location`},
+		"duplicate subnet":  {"10.0.0.0/16", `["gbr-1"]`, `[{ name = "a", cidr_block = "10.0.0.0/24", location = "gbr-1" }, { name = "a", cidr_block = "10.0.1.0/24", location = "gbr-1" }]`, `Duplicate Subnet`},
+		"overlapping range": {"10.0.0.0/16", `["gbr-1"]`, `[{ name = "a", cidr_block = "10.0.0.0/23", location = "gbr-1" }, { name = "b", cidr_block = "10.0.1.0/24", location = "gbr-1" }]`, `Overlapping Subnets`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_network" "test" {
+  name       = "test"
+  cidr_block = %q
+  locations  = %s
+  subnets    = %s
+}
+`, tc.cidrBlock, tc.locations, tc.subnets),
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccFunctionResource_networkValidation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+  }
+  network = {
+    network_id = "network-1"
+    egress     = "public"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Egress Mode`),
+			},
+		},
+	})
+}
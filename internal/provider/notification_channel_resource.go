@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &notificationChannelResource{}
+	_ resource.ResourceWithConfigure      = &notificationChannelResource{}
+	_ resource.ResourceWithImportState    = &notificationChannelResource{}
+	_ resource.ResourceWithValidateConfig = &notificationChannelResource{}
+)
+
+func NewNotificationChannelResource() resource.Resource {
+	return &notificationChannelResource{}
+}
+
+type notificationChannelResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	EmailAddresses types.List   `tfsdk:"email_addresses"`
+	URL            types.String `tfsdk:"url"`
+	LastUpdated    types.String `tfsdk:"last_updated"`
+}
+
+type notificationChannelResource struct {
+	client *coderforge.Client
+}
+
+func (r *notificationChannelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_channel"
+}
+
+func (r *notificationChannelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email_addresses": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			// Webhook URLs, Slack's in particular, carry their credentials.
+			"url": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the attributes set match the channel type.
+func (r *notificationChannelResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config notificationChannelResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !knownString(config.Type) {
+		return
+	}
+	switch config.Type.ValueString() {
+	case coderforge.NotificationEmail:
+		if config.EmailAddresses.IsNull() || (!config.EmailAddresses.IsUnknown() && len(config.EmailAddresses.Elements()) == 0) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email_addresses"),
+				"Missing Notification Channel Attribute",
+				"An email notification channel requires at least one email address.",
+			)
+		}
+		if !config.URL.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("url"),
+				"Unexpected Notification Channel Attribute",
+				"An email notification channel does not support url.",
+			)
+		}
+		for i, address := range config.EmailAddresses.Elements() {
+			address, ok := address.(types.String)
+			if !ok || !knownString(address) {
+				continue
+			}
+			if parsed, err := mail.ParseAddress(address.ValueString()); err != nil || parsed.Address != address.ValueString() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("email_addresses").AtListIndex(i),
+					"Invalid Email Address",
+					"The email address must be a plain address such as \"oncall@example.com\", got: "+address.ValueString(),
+				)
+			}
+		}
+	case coderforge.NotificationWebhook, coderforge.NotificationSlack:
+		if config.URL.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("url"),
+				"Missing Notification Channel Attribute",
+				fmt.Sprintf("A %s notification channel requires url.", config.Type.ValueString()),
+			)
+		}
+		if !config.EmailAddresses.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("email_addresses"),
+				"Unexpected Notification Channel Attribute",
+				fmt.Sprintf("A %s notification channel does not support email_addresses.", config.Type.ValueString()),
+			)
+		}
+		if knownString(config.URL) {
+			// The URL is sensitive, so it is left out of the message.
+			if u, err := url.Parse(config.URL.ValueString()); err != nil || u.Scheme != "https" || u.Host == "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("url"),
+					"Invalid Webhook URL",
+					"The url must be an https URL.",
+				)
+			}
+		}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid Notification Channel Type",
+			"The type must be one of email, webhook or slack, got: "+config.Type.ValueString(),
+		)
+	}
+}
+
+func (r *notificationChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan notificationChannelResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := notificationChannelItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	channel, err := r.client.NotificationChannels().Create(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating notification channel",
+			"Could not create notification channel, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newNotificationChannelModel(ctx, channel)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *notificationChannelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state notificationChannelResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	channel, err := r.client.NotificationChannels().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Notification Channel",
+			"Could not read notification channel ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The channel was deleted outside Terraform, plan to create it again.
+	if channel == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state, diags = newNotificationChannelModel(ctx, channel)
+	resp.Diagnostics.Append(diags...)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *notificationChannelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan notificationChannelResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state notificationChannelResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := notificationChannelItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	item.ID = state.ID.ValueString()
+	channel, err := r.client.NotificationChannels().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating notification channel",
+			"Could not update notification channel, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newNotificationChannelModel(ctx, channel)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *notificationChannelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state notificationChannelResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.NotificationChannels().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting notification channel",
+			"Could not delete notification channel, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *notificationChannelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *notificationChannelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func notificationChannelItem(ctx context.Context, plan notificationChannelResourceModel) (coderforge.NotificationChannel, diag.Diagnostics) {
+	channel := coderforge.NotificationChannel{
+		Name:        plan.Name.ValueString(),
+		ChannelType: plan.Type.ValueString(),
+		URL:         plan.URL.ValueString(),
+	}
+	var diags diag.Diagnostics
+	if !plan.EmailAddresses.IsNull() {
+		diags = plan.EmailAddresses.ElementsAs(ctx, &channel.EmailAddresses, false)
+	}
+	return channel, diags
+}
+
+func newNotificationChannelModel(ctx context.Context, channel *coderforge.NotificationChannel) (notificationChannelResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	emailAddresses := types.ListNull(types.StringType)
+	if len(channel.EmailAddresses) > 0 {
+		emailAddresses, diags = types.ListValueFrom(ctx, types.StringType, channel.EmailAddresses)
+	}
+	return notificationChannelResourceModel{
+		ID:             types.StringValue(channel.ID),
+		Name:           types.StringValue(channel.Name),
+		Type:           types.StringValue(channel.ChannelType),
+		EmailAddresses: emailAddresses,
+		URL:            stringValueOrNull(channel.URL),
+	}, diags
+}
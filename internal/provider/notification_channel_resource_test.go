@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNotificationChannelResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "notification_channel"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_notification_channel" "oncall" {
+  name            = "oncall"
+  type            = "email"
+  email_addresses = ["oncall@example.com"]
+}
+
+resource "coderforge_notification_channel" "slack" {
+  name = "slack"
+  type = "slack"
+  url  = "https://hooks.slack.com/services/T000/B000/XXXX"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_notification_channel.oncall", "id"),
+					resource.TestCheckResourceAttr("coderforge_notification_channel.oncall", "email_addresses.0", "oncall@example.com"),
+					resource.TestCheckNoResourceAttr("coderforge_notification_channel.oncall", "url"),
+					resource.TestCheckResourceAttr("coderforge_notification_channel.slack", "url", "https://hooks.slack.com/services/T000/B000/XXXX"),
+					testAccCheckItemField(server, "coderforge_notification_channel.slack", "channelType", "slack"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_notification_channel.oncall",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Add an address in place.
+			{
+				Config: testAccProviderConfig(server) + `
+resource "coderforge_notification_channel" "oncall" {
+  name            = "oncall"
+  type            = "email"
+  email_addresses = ["oncall@example.com", "lead@example.com"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_notification_channel.oncall", "email_addresses.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNotificationChannelResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		channel string
+		err     string
+	}{
+		"unknown type":       {`type = "pager"`, `Invalid Notification Channel Type`},
+		"email without list": {`type = "email"`, `at least one email address`},
+		"empty email list":   {"type = \"email\"\n  email_addresses = []", `at least one email address`},
+		"bad address":        {"type = \"email\"\n  email_addresses = [\"Oncall <oncall@example.com>\"]", `Invalid Email Address`},
+		"email with url":     {"type = \"email\"\n  email_addresses = [\"a@example.com\"]\n  url = \"https://example.com\"", `does not support url`},
+		"webhook no url":     {`type = "webhook"`, `requires url`},
+		"webhook over http":  {"type = \"webhook\"\n  url = \"http://example.com/hook\"", `Invalid Webhook URL`},
+		"slack with emails":  {"type = \"slack\"\n  url = \"https://hooks.slack.com/x\"\n  email_addresses = [\"a@example.com\"]", `does not support email_addresses`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_notification_channel" "test" {
+  name = "test"
+  ` + tc.channel + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
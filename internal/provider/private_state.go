@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// Keys of the values resources keep in private state.
+const (
+	privateIdempotencyKey = "idempotency_key"
+	privateVersion        = "version"
+	privateSecretSalt     = "secret_salt"
+)
+
+// privateState is the private state of a resource request or response.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// idempotencyKey returns the idempotency key kept in private state, or ""
+// if there is none.
+func idempotencyKey(ctx context.Context, private privateState) (string, diag.Diagnostics) {
+	return getPrivateString(ctx, private, privateIdempotencyKey)
+}
+
+func setIdempotencyKey(ctx context.Context, private privateState, key string) diag.Diagnostics {
+	return setPrivateString(ctx, private, privateIdempotencyKey, key)
+}
+
+// getVersion returns the API version of the item last read or written, or ""
+// if there is none, for example in state written by an older provider.
+func getVersion(ctx context.Context, private privateState) (string, diag.Diagnostics) {
+	return getPrivateString(ctx, private, privateVersion)
+}
+
+func setVersion(ctx context.Context, private privateState, version string) diag.Diagnostics {
+	return setPrivateString(ctx, private, privateVersion, version)
+}
+
+// getSecretSalt returns the salt of a secret's value hash, or "" if there is
+// none.
+func getSecretSalt(ctx context.Context, private privateState) (string, diag.Diagnostics) {
+	return getPrivateString(ctx, private, privateSecretSalt)
+}
+
+func setSecretSalt(ctx context.Context, private privateState, salt string) diag.Diagnostics {
+	return setPrivateString(ctx, private, privateSecretSalt, salt)
+}
+
+// addConflictError reports a write the API refused because the item changed
+// since Terraform last read it.
+func addConflictError(diags *diag.Diagnostics, id string, err error) {
+	diags.AddError(
+		"Resource Changed Outside Terraform",
+		"Resource "+id+" was changed outside Terraform since it was last read. "+
+			"Refresh the state, for example with terraform plan, review the changes and apply again.\n\n"+
+			"API error: "+err.Error(),
+	)
+}
+
+// getPrivateString reads a string stored with setPrivateString.
+func getPrivateString(ctx context.Context, private privateState, key string) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, key)
+	if diags.HasError() || len(value) == 0 {
+		return "", diags
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		diags.AddError(
+			"Invalid Private State",
+			"Could not decode private state key "+key+": "+err.Error(),
+		)
+	}
+	return s, diags
+}
+
+// setPrivateString stores s under key. Private state values must be JSON.
+// An empty s removes the key.
+func setPrivateString(ctx context.Context, private privateState, key string, s string) diag.Diagnostics {
+	if s == "" {
+		return private.SetKey(ctx, key, nil)
+	}
+	value, _ := json.Marshal(s)
+	return private.SetKey(ctx, key, value)
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
@@ -9,7 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io/fs"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"terraform-provider-coderforge/pkg/coderforge"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -28,12 +35,38 @@ func New(version string) func() provider.Provider {
 
 // coderforgeProviderModel maps provider schema data to a Go type.
 type coderforgeProviderModel struct {
-	Token      types.String   `tfsdk:"token"`
-	CloudSpace types.String   `tfsdk:"cloud_space"`
-	Locations  []types.String `tfsdk:"locations"`
-	StackId    types.String   `tfsdk:"stack_id"`
+	Profile         types.String   `tfsdk:"profile"`
+	CredentialsFile types.String   `tfsdk:"credentials_file"`
+	Endpoint        types.String   `tfsdk:"endpoint"`
+	Token           types.String   `tfsdk:"token"`
+	TokenFile       types.String   `tfsdk:"token_file"`
+	TokenCommand    types.String   `tfsdk:"token_command"`
+	ClientId        types.String   `tfsdk:"client_id"`
+	ClientSecret    types.String   `tfsdk:"client_secret"`
+	TokenURL        types.String   `tfsdk:"token_url"`
+	Scopes          []types.String `tfsdk:"scopes"`
+	OIDCToken       types.String   `tfsdk:"oidc_token"`
+	OIDCTokenFile   types.String   `tfsdk:"oidc_token_file"`
+	OIDCAudience    types.String   `tfsdk:"oidc_audience"`
+	OIDCRole        types.String   `tfsdk:"oidc_role"`
+	CloudSpace      types.String   `tfsdk:"cloud_space"`
+	Locations       []types.String `tfsdk:"locations"`
+	StackId         types.String   `tfsdk:"stack_id"`
+	RequestTimeout  types.String   `tfsdk:"request_timeout"`
+	MaxRequestRate  types.Float64  `tfsdk:"max_requests_per_second"`
+	ReadCache       types.Bool     `tfsdk:"read_cache"`
+	BulkReads       types.Bool     `tfsdk:"bulk_reads"`
 }
 
+// Authentication methods, in the order they are considered.
+const (
+	authMethodToken             = "token"
+	authMethodClientCredentials = "client_credentials"
+	authMethodWorkloadIdentity  = "workload_identity"
+	authMethodTokenFile         = "token_file"
+	authMethodTokenCommand      = "token_command"
+)
+
 // coderforgeProvider is the provider implementation.
 type coderforgeProvider struct {
 	// version is set to the provider version on release, "dev" when the
@@ -52,12 +85,54 @@ func (p *coderforgeProvider) Metadata(_ context.Context, _ provider.MetadataRequ
 func (p *coderforgeProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"profile": schema.StringAttribute{
+				Optional: true,
+			},
+			"credentials_file": schema.StringAttribute{
+				Optional: true,
+			},
+			"endpoint": schema.StringAttribute{
+				Optional: true,
+			},
 			"token": schema.StringAttribute{
 				Optional:  true,
 				Sensitive: true,
 			},
+			"token_file": schema.StringAttribute{
+				Optional: true,
+			},
+			"token_command": schema.StringAttribute{
+				Optional: true,
+			},
+			"client_id": schema.StringAttribute{
+				Optional: true,
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"token_url": schema.StringAttribute{
+				Optional: true,
+			},
+			"scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"oidc_token": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"oidc_token_file": schema.StringAttribute{
+				Optional: true,
+			},
+			"oidc_audience": schema.StringAttribute{
+				Optional: true,
+			},
+			"oidc_role": schema.StringAttribute{
+				Optional: true,
+			},
 			"cloud_space": schema.StringAttribute{
-				Required: true,
+				Optional: true,
 			},
 			"locations": schema.ListAttribute{
 				ElementType: types.StringType,
@@ -66,6 +141,18 @@ func (p *coderforgeProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			"stack_id": schema.StringAttribute{
 				Optional: true,
 			},
+			"request_timeout": schema.StringAttribute{
+				Optional: true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				Optional: true,
+			},
+			"read_cache": schema.BoolAttribute{
+				Optional: true,
+			},
+			"bulk_reads": schema.BoolAttribute{
+				Optional: true,
+			},
 		},
 	}
 }
@@ -81,30 +168,182 @@ func (p *coderforgeProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	var token string
+	// Settings missing from the configuration fall back to the environment
+	// and then to the selected profile of the shared credentials file. A
+	// profile the configuration names outranks the environment instead.
+	profile := &coderforge.Profile{}
+	profileName := valueOrEnv(config.Profile, "CODERFORGE_PROFILE")
+	credentialsFile := valueOrEnv(config.CredentialsFile, "CODERFORGE_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		credentialsFile = coderforge.DefaultCredentialsFile
+	}
 
-	if !config.Token.IsNull() {
-		token = config.Token.ValueString()
-	} else {
-		token = os.Getenv("CODERFORGE_CLOUD_TOKEN")
+	if profileName != "" {
+		loaded, err := coderforge.LoadProfile(credentialsFile, profileName)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				"Unable to load CoderForge.org API profile",
+				"The provider cannot load the profile \""+profileName+"\" from the credentials file "+credentialsFile+". "+
+					"Check the profile name, set credentials_file or use the CODERFORGE_CREDENTIALS_FILE environment variable.\n\n"+
+					"Error: "+err.Error(),
+			)
+			return
+		}
+		profile = loaded
+	} else if loaded, err := coderforge.LoadProfile(credentialsFile, coderforge.DefaultProfile); err == nil {
+		profile = loaded
+	} else if !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, coderforge.ErrProfileNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credentials_file"),
+			"Unable to load CoderForge.org API credentials file",
+			"The provider cannot read the default profile from the credentials file "+credentialsFile+".\n\n"+
+				"Error: "+err.Error(),
+		)
+		return
+	}
+
+	endpoint := profileValueOrEnv(config, config.Endpoint, "CODERFORGE_ENDPOINT", profile.Endpoint)
+	if endpoint == "" {
+		endpoint = coderforge.HostURL
 	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	var tokenSource coderforge.TokenSource
+
+	switch selectAuthMethod(config, profile) {
+	case authMethodToken:
+		token := profileValueOrEnv(config, config.Token, "CODERFORGE_CLOUD_TOKEN", profile.Token)
+		tokenSource = coderforge.StaticTokenSource(token)
+	case authMethodClientCredentials:
+		clientSecret := valueOrEnv(config.ClientSecret, "CODERFORGE_CLIENT_SECRET")
+		tokenURL := valueOrEnv(config.TokenURL, "CODERFORGE_TOKEN_URL")
+		if clientSecret == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("client_secret"),
+				"Missing CoderForge.org API client_secret",
+				"The client_id is set, so the provider authenticates with OAuth2 client credentials and needs a client secret. "+
+					"Set client_secret in the configuration or use the CODERFORGE_CLIENT_SECRET environment variable.",
+			)
+		}
+		if tokenURL == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_url"),
+				"Missing CoderForge.org API token_url",
+				"The client_id is set, so the provider authenticates with OAuth2 client credentials and needs the token endpoint of the identity provider. "+
+					"Set token_url in the configuration or use the CODERFORGE_TOKEN_URL environment variable.",
+			)
+		}
+		var scopes []string
+		for _, scope := range config.Scopes {
+			scopes = append(scopes, scope.ValueString())
+		}
+		tokenSource = &coderforge.ClientCredentialsTokenSource{
+			TokenURL:     tokenURL,
+			ClientID:     valueOrEnv(config.ClientId, "CODERFORGE_CLIENT_ID"),
+			ClientSecret: clientSecret,
+			Scopes:       scopes,
+		}
+	case authMethodWorkloadIdentity:
+		audience := valueOrEnv(config.OIDCAudience, "CODERFORGE_OIDC_AUDIENCE")
+		tokenURL := valueOrEnv(config.TokenURL, "CODERFORGE_TOKEN_URL")
+		if tokenURL == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_url"),
+				"Missing CoderForge.org API token_url",
+				"The oidc_role is set, so the provider exchanges an OIDC identity token issued by the CI system for a CoderForge.org token and needs the token exchange endpoint of the cloud space. "+
+					"Set token_url in the configuration or use the CODERFORGE_TOKEN_URL environment variable.",
+			)
+		}
 
-	if token == "" {
+		var identityToken coderforge.TokenSource
+		if oidcToken := valueOrEnv(config.OIDCToken, "CODERFORGE_OIDC_TOKEN"); oidcToken != "" {
+			identityToken = coderforge.StaticTokenSource(oidcToken)
+		} else if oidcTokenFile := valueOrEnv(config.OIDCTokenFile, "CODERFORGE_OIDC_TOKEN_FILE"); oidcTokenFile != "" {
+			identityToken = &coderforge.FileTokenSource{Path: oidcTokenFile}
+		} else if requestURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"); requestURL != "" {
+			identityToken = &coderforge.GitHubActionsTokenSource{
+				RequestURL:   requestURL,
+				RequestToken: os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN"),
+				Audience:     audience,
+			}
+		} else {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("oidc_token_file"),
+				"Missing CI identity token",
+				"The oidc_role is set, so the provider exchanges an OIDC identity token issued by the CI system for a CoderForge.org token, but no identity token was found. "+
+					"Set oidc_token or oidc_token_file, use the CODERFORGE_OIDC_TOKEN or CODERFORGE_OIDC_TOKEN_FILE environment variable, "+
+					"or grant the GitHub Actions workflow the id-token: write permission.",
+			)
+			break
+		}
+
+		tokenSource = &coderforge.TokenExchangeTokenSource{
+			TokenURL:      tokenURL,
+			Audience:      audience,
+			Role:          valueOrEnv(config.OIDCRole, "CODERFORGE_OIDC_ROLE"),
+			IdentityToken: identityToken,
+		}
+	case authMethodTokenFile:
+		tokenSource = &coderforge.FileTokenSource{Path: valueOrEnv(config.TokenFile, "CODERFORGE_TOKEN_FILE")}
+	case authMethodTokenCommand:
+		tokenSource = &coderforge.CommandTokenSource{Command: valueOrEnv(config.TokenCommand, "CODERFORGE_TOKEN_COMMAND")}
+	default:
 		resp.Diagnostics.AddAttributeError(
 			path.Root("token"),
-			"Missing CoderForge.org API API Password",
-			"The provider cannot create the CoderForge.org API API client as there is a missing or empty value for the CoderForge.org API token. "+
-				"Set the token value in the configuration or use the CODERFORGE_PASSWORD environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			"Missing CoderForge.org API credentials",
+			"The provider cannot create the CoderForge.org API client as no credentials are configured. "+
+				"Set one of token, client_id and client_secret, oidc_role, token_file or token_command in the configuration, "+
+				"use the CODERFORGE_CLOUD_TOKEN, CODERFORGE_CLIENT_ID, CODERFORGE_OIDC_ROLE, CODERFORGE_TOKEN_FILE or CODERFORGE_TOKEN_COMMAND environment variable, "+
+				"or select a profile with a token. "+
+				"If one is already set, ensure the value is not empty.",
 		)
 	}
 
-	if config.CloudSpace.IsNull() {
+	cloudSpace := config.CloudSpace.ValueString()
+	if cloudSpace == "" {
+		cloudSpace = profile.CloudSpace
+	}
+
+	if cloudSpace == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("cloud_space"),
 			"Missing CoderForge.org API API cloud_space",
 			"The provider cannot create the CoderForge.org API API client as there is a missing or empty value for the CoderForge.org API cloud_space. "+
-				"Set the cloud_space inside the provider.",
+				"Set the cloud_space inside the provider or in the selected profile.",
+		)
+	}
+
+	var requestTimeout time.Duration
+	requestTimeoutValue := valueOrEnv(config.RequestTimeout, "CODERFORGE_REQUEST_TIMEOUT")
+
+	if requestTimeoutValue != "" {
+		var err error
+		requestTimeout, err = time.ParseDuration(requestTimeoutValue)
+		if err != nil || requestTimeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid CoderForge.org API request_timeout",
+				"The request_timeout must be a positive duration such as \"30s\" or \"5m\", got: "+requestTimeoutValue,
+			)
+		}
+	}
+
+	maxRequestRate := config.MaxRequestRate.ValueFloat64()
+	if config.MaxRequestRate.IsNull() {
+		if value := os.Getenv("CODERFORGE_MAX_REQUESTS_PER_SECOND"); value != "" {
+			var err error
+			maxRequestRate, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				maxRequestRate = -1
+			}
+		}
+	}
+	if maxRequestRate < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_requests_per_second"),
+			"Invalid CoderForge.org API max_requests_per_second",
+			"The max_requests_per_second must be a positive number, or 0 for no limit.",
 		)
 	}
 
@@ -112,11 +351,14 @@ func (p *coderforgeProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	var cloudSpace = config.CloudSpace.ValueString()
 	var stackId = config.StackId.ValueString()
+	if stackId == "" {
+		stackId = profile.StackId
+	}
 
-	ctx = tflog.SetField(ctx, "coderforge_cloud_token", token)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "coderforge_password")
+	ctx = tflog.SetField(ctx, "coderforge_auth_method", selectAuthMethod(config, profile))
+	ctx = tflog.SetField(ctx, "coderforge_profile", profileName)
+	ctx = tflog.SetField(ctx, "coderforge_endpoint", endpoint)
 
 	tflog.Debug(ctx, "Creating CoderForge.org client")
 
@@ -124,9 +366,28 @@ func (p *coderforgeProvider) Configure(ctx context.Context, req provider.Configu
 	for _, location := range config.Locations {
 		locations = append(locations, location.ValueString())
 	}
+	if config.Locations == nil {
+		locations = profile.Locations
+	}
 
 	// Create a new CoderForge.org client using the configuration values
-	client, err := NewClient(&token, &cloudSpace, &locations, &stackId)
+	opts := []coderforge.Option{
+		coderforge.WithHostURL(endpoint),
+		coderforge.WithTokenSource(tokenSource),
+		coderforge.WithStackId(stackId),
+		coderforge.WithLocations(locations),
+		coderforge.WithRequestTimeout(requestTimeout),
+		coderforge.WithRateLimit(maxRequestRate),
+	}
+	// The cache and the bulk snapshots live as long as this provider
+	// instance, which Terraform starts anew for every plan and apply.
+	if config.ReadCache.ValueBool() || (config.ReadCache.IsNull() && os.Getenv("CODERFORGE_READ_CACHE") == "true") {
+		opts = append(opts, coderforge.WithReadCache())
+	}
+	if config.BulkReads.ValueBool() || (config.BulkReads.IsNull() && os.Getenv("CODERFORGE_BULK_READS") == "true") {
+		opts = append(opts, coderforge.WithBulkReads())
+	}
+	client, err := coderforge.NewClient(cloudSpace, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create CoderForge.org API Client",
@@ -145,14 +406,95 @@ func (p *coderforgeProvider) Configure(ctx context.Context, req provider.Configu
 	tflog.Info(ctx, "Configured CoderForge.org client", map[string]any{"success": true})
 }
 
+// selectAuthMethod picks how the client authenticates. Credentials set in
+// the configuration take precedence over the environment, so an exported
+// CODERFORGE_CLOUD_TOKEN does not override an explicit client_id, and the
+// environment takes precedence over the profile unless the configuration
+// names the profile.
+func selectAuthMethod(config coderforgeProviderModel, profile *coderforge.Profile) string {
+	switch {
+	case config.Token.ValueString() != "":
+		return authMethodToken
+	case config.ClientId.ValueString() != "":
+		return authMethodClientCredentials
+	case config.OIDCRole.ValueString() != "", config.OIDCToken.ValueString() != "", config.OIDCTokenFile.ValueString() != "":
+		return authMethodWorkloadIdentity
+	case config.TokenFile.ValueString() != "":
+		return authMethodTokenFile
+	case config.TokenCommand.ValueString() != "":
+		return authMethodTokenCommand
+	case !config.Profile.IsNull() && profile.Token != "":
+		return authMethodToken
+	case os.Getenv("CODERFORGE_CLOUD_TOKEN") != "":
+		return authMethodToken
+	case os.Getenv("CODERFORGE_CLIENT_ID") != "":
+		return authMethodClientCredentials
+	case os.Getenv("CODERFORGE_OIDC_ROLE") != "", os.Getenv("CODERFORGE_OIDC_TOKEN") != "", os.Getenv("CODERFORGE_OIDC_TOKEN_FILE") != "":
+		return authMethodWorkloadIdentity
+	case os.Getenv("CODERFORGE_TOKEN_FILE") != "":
+		return authMethodTokenFile
+	case os.Getenv("CODERFORGE_TOKEN_COMMAND") != "":
+		return authMethodTokenCommand
+	case profile.Token != "":
+		return authMethodToken
+	}
+	return ""
+}
+
+// valueOrEnv returns the configured value, or the named environment
+// variable when the attribute is not set.
+func valueOrEnv(value types.String, key string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return os.Getenv(key)
+}
+
+// profileValueOrEnv returns the configured value, or else the environment
+// variable or the profile's value, whichever comes first. The profile comes
+// first if the configuration names it, so a profile picked for one
+// configuration is not overridden by variables exported for another.
+func profileValueOrEnv(config coderforgeProviderModel, value types.String, key string, profileValue string) string {
+	if value.ValueString() != "" {
+		return value.ValueString()
+	}
+	if !config.Profile.IsNull() && profileValue != "" {
+		return profileValue
+	}
+	if env := os.Getenv(key); env != "" {
+		return env
+	}
+	return profileValue
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *coderforgeProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewFunctionsDataSource,
+		NewFunctionInvocationDataSource,
+		NewFunctionLogsDataSource,
+		NewUserDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *coderforgeProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewFunctionResource,
+		NewStackDeploymentResource,
+		NewFunctionAliasResource,
+		NewScheduleResource,
+		NewEventSourceResource,
+		NewDomainResource,
+		NewDomainValidationResource,
+		NewLogDrainResource,
+		NewNotificationChannelResource,
+		NewAlertResource,
+		NewSecretResource,
+		NewAccessPolicyResource,
+		NewAPIKeyResource,
+		NewRoleBindingResource,
+		NewNetworkResource,
+		NewVolumeResource,
 	}
 }
@@ -4,10 +4,22 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-coderforge/internal/fakeserver"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -15,11 +27,156 @@ import (
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"coderforge": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccToken is the bearer token the fake API accepts in acceptance tests.
+const testAccToken = "test-token"
+
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	// Acceptance tests run against the in-repo fake API, so they only need
+	// the Terraform CLI. Skip rather than fail where it is not installed, as
+	// the test framework would otherwise try to download it.
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform CLI not found in PATH, set TF_ACC_TERRAFORM_PATH to run acceptance tests")
+	}
+}
+
+// testAccFakeServer starts a fake CoderForge.org API for the duration of the
+// test.
+func testAccFakeServer(t *testing.T) *fakeserver.Server {
+	t.Helper()
+
+	server := fakeserver.New()
+	server.Token = testAccToken
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testAccProviderConfig returns a provider block pointing at the fake API.
+func testAccProviderConfig(server *fakeserver.Server) string {
+	return fmt.Sprintf(`
+provider "coderforge" {
+  endpoint                = %[1]q
+  token                   = %[2]q
+  credentials_file        = "/nonexistent/credentials"
+  cloud_space             = "test.coderforge.org"
+  stack_id                = "stack-test"
+  locations               = ["gbr-1", "gbr-2"]
+  max_requests_per_second = 100
+  read_cache              = true
+  bulk_reads              = true
+}
+`, server.URL, testAccToken)
+}
+
+func TestAccProvider_workloadIdentity(t *testing.T) {
+	server := testAccFakeServer(t)
+	t.Setenv("CODERFORGE_TOKEN_URL", "")
+
+	var exchanged int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("subject_token") != "ci-jwt" || r.FormValue("role") != "deployer" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		atomic.AddInt32(&exchanged, 1)
+		_, _ = fmt.Fprintf(w, `{"access_token":%q,"expires_in":900}`, testAccToken)
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	config := func(tokenURL string) string {
+		return fmt.Sprintf(`
+provider "coderforge" {
+  endpoint         = %[1]q
+  oidc_role        = "deployer"
+  oidc_token       = "ci-jwt"
+  token_url        = %[2]q
+  credentials_file = "/nonexistent/credentials"
+  cloud_space      = "test.coderforge.org"
+  stack_id         = "stack-test"
+}
+`, server.URL, tokenURL) + testAccFunctionResourceConfig("hello:1", 30)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			// The token exchange endpoint is not derived from the endpoint.
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile(`Missing CoderForge.org API token_url`),
+			},
+			{
+				Config: config(tokenServer.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_function.test", "id"),
+					func(*terraform.State) error {
+						if atomic.LoadInt32(&exchanged) == 0 {
+							return fmt.Errorf("expected the identity token to be exchanged")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_profilePrecedence(t *testing.T) {
+	server := testAccFakeServer(t)
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	credentials := fmt.Sprintf("[test]\nendpoint = %s\ntoken = %s\n", server.URL, testAccToken)
+	if err := os.WriteFile(credentialsFile, []byte(credentials), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CODERFORGE_ENDPOINT", server.URL)
+	t.Setenv("CODERFORGE_CLOUD_TOKEN", "exported-token")
+
+	config := func(profile string) string {
+		return fmt.Sprintf(`
+provider "coderforge" {
+  %[1]s
+  credentials_file = %[2]q
+  cloud_space      = "test.coderforge.org"
+  stack_id         = "stack-test"
+}
+`, profile, credentialsFile) + testAccFunctionResourceConfig("hello:1", 30)
+	}
+
+	// A profile selected by the environment ranks below the exported token.
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv("CODERFORGE_PROFILE", "test")
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			Steps: []resource.TestStep{
+				{
+					Config:      config(""),
+					ExpectError: regexp.MustCompile(`401`),
+				},
+			},
+		})
+	})
+
+	// A profile named in the configuration outranks it.
+	t.Run("configuration", func(t *testing.T) {
+		resource.UnitTest(t, resource.TestCase{
+			PreCheck:                 func() { testAccPreCheck(t) },
+			ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+			CheckDestroy:             testAccCheckFunctionDestroy(server),
+			Steps: []resource.TestStep{
+				{
+					Config: config(`profile = "test"`),
+					Check:  resource.TestCheckResourceAttrSet("coderforge_function.test", "id"),
+				},
+			},
+		})
+	})
 }
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &roleBindingResource{}
+	_ resource.ResourceWithConfigure      = &roleBindingResource{}
+	_ resource.ResourceWithImportState    = &roleBindingResource{}
+	_ resource.ResourceWithValidateConfig = &roleBindingResource{}
+)
+
+// memberPattern matches the principals roles can be granted to, such as
+// "user:user-1" or "group:developers".
+var memberPattern = regexp.MustCompile(`^(user|group):\S+$`)
+
+// roles are the roles a binding can grant, from least to most privileged.
+var roles = []string{coderforge.RoleViewer, coderforge.RoleDeployer, coderforge.RoleAdmin}
+
+func NewRoleBindingResource() resource.Resource {
+	return &roleBindingResource{}
+}
+
+type roleBindingResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Principal   types.String `tfsdk:"principal"`
+	Role        types.String `tfsdk:"role"`
+	StackId     types.String `tfsdk:"stack_id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+type roleBindingResource struct {
+	client *coderforge.Client
+}
+
+func (r *roleBindingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_binding"
+}
+
+func (r *roleBindingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required: true,
+			},
+			// Without a stack_id, the role is granted on the whole cloud
+			// space.
+			"stack_id": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the principal and the role at plan time.
+func (r *roleBindingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config roleBindingResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if knownString(config.Principal) && !memberPattern.MatchString(config.Principal.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("principal"),
+			"Invalid Principal",
+			"The principal must be written \"user:<id>\" or \"group:<name>\", got: "+config.Principal.ValueString(),
+		)
+	}
+	if knownString(config.Role) && !slices.Contains(roles, config.Role.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role"),
+			"Invalid Role",
+			"The role must be viewer, deployer or admin, got: "+config.Role.ValueString(),
+		)
+	}
+	if knownString(config.StackId) && config.StackId.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("stack_id"),
+			"Invalid Stack ID",
+			"The stack_id must not be empty. Leave it out to grant the role on the cloud space.",
+		)
+	}
+}
+
+func (r *roleBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleBindingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	binding, err := r.client.RoleBindings().Create(ctx, roleBindingItem(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating role binding",
+			"Could not create role binding, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newRoleBindingModel(binding)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *roleBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleBindingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	binding, err := r.client.RoleBindings().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Role Binding",
+			"Could not read role binding ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The binding was removed outside Terraform, plan to grant the role
+	// again.
+	if binding == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state = newRoleBindingModel(binding)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *roleBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan roleBindingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state roleBindingResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := roleBindingItem(plan)
+	item.ID = state.ID.ValueString()
+	binding, err := r.client.RoleBindings().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating role binding",
+			"Could not update role binding, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newRoleBindingModel(binding)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *roleBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleBindingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RoleBindings().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting role binding",
+			"Could not delete role binding, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *roleBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *roleBindingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func roleBindingItem(plan roleBindingResourceModel) coderforge.RoleBinding {
+	return coderforge.RoleBinding{
+		Principal: plan.Principal.ValueString(),
+		Role:      plan.Role.ValueString(),
+		StackId:   plan.StackId.ValueString(),
+	}
+}
+
+func newRoleBindingModel(binding *coderforge.RoleBinding) roleBindingResourceModel {
+	return roleBindingResourceModel{
+		ID:        types.StringValue(binding.ID),
+		Principal: types.StringValue(binding.Principal),
+		Role:      types.StringValue(binding.Role),
+		StackId:   stringValueOrNull(binding.StackId),
+	}
+}
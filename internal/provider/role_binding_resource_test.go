@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRoleBindingResource(t *testing.T) {
+	server := testAccFakeServer(t)
+	server.AddUser("owner@example.com", "Owner")
+	server.AddUser("jane@example.com", "Jane Doe")
+
+	var bindingID string
+	config := func(role string) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+data "coderforge_user" "jane" {
+  email = "jane@example.com"
+}
+
+resource "coderforge_role_binding" "jane" {
+  principal = "user:${data.coderforge_user.jane.id}"
+  role      = %q
+}
+
+resource "coderforge_role_binding" "developers" {
+  principal = "group:developers"
+  role      = "deployer"
+  stack_id  = "payments"
+}
+`, role)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "role_binding"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config("viewer"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_role_binding.jane", "id"),
+					resource.TestCheckResourceAttr("coderforge_role_binding.jane", "principal", "user:user-2"),
+					resource.TestCheckNoResourceAttr("coderforge_role_binding.jane", "stack_id"),
+					resource.TestCheckResourceAttr("coderforge_role_binding.developers", "stack_id", "payments"),
+					testAccCheckItemField(server, "coderforge_role_binding.developers", "stackId", "payments"),
+					testAccCaptureID("coderforge_role_binding.jane", &bindingID),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_role_binding.developers",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// The role is changed in place.
+			{
+				Config: config("admin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("coderforge_role_binding.jane", "id", &bindingID),
+					testAccCheckItemField(server, "coderforge_role_binding.jane", "role", "admin"),
+				),
+			},
+			// A binding removed outside Terraform is granted again.
+			{
+				PreConfig: func() { server.RemoveResource(bindingID) },
+				Config:    config("admin"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAttributeChanged("coderforge_role_binding.jane", "id", &bindingID),
+					testAccCheckItemField(server, "coderforge_role_binding.jane", "role", "admin"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRoleBindingResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		binding string
+		err     string
+	}{
+		"bad principal": {"principal = \"api_key:k\"\n  role = \"viewer\"", `Invalid Principal`},
+		"bad role":      {"principal = \"group:developers\"\n  role = \"owner\"", `Invalid Role`},
+		"empty stack":   {"principal = \"group:developers\"\n  role = \"viewer\"\n  stack_id = \"\"", `Invalid Stack ID`},
+		"unknown user":  {"principal = \"user:user-9\"\n  role = \"viewer\"", `user user-9 not found`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_role_binding" "test" {
+  ` + tc.binding + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &scheduleResource{}
+	_ resource.ResourceWithConfigure      = &scheduleResource{}
+	_ resource.ResourceWithImportState    = &scheduleResource{}
+	_ resource.ResourceWithValidateConfig = &scheduleResource{}
+)
+
+func NewScheduleResource() resource.Resource {
+	return &scheduleResource{}
+}
+
+type scheduleResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Cron        types.String `tfsdk:"cron"`
+	Rate        types.String `tfsdk:"rate"`
+	Timezone    types.String `tfsdk:"timezone"`
+	FunctionId  types.String `tfsdk:"function_id"`
+	Payload     types.String `tfsdk:"payload"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+type scheduleResource struct {
+	client *coderforge.Client
+}
+
+func (r *scheduleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schedule"
+}
+
+func (r *scheduleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cron": schema.StringAttribute{
+				Optional: true,
+			},
+			"rate": schema.StringAttribute{
+				Optional: true,
+			},
+			"timezone": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("UTC"),
+			},
+			"function_id": schema.StringAttribute{
+				Required: true,
+			},
+			"payload": schema.StringAttribute{
+				Optional: true,
+			},
+			"enabled": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the expression, timezone and payload at plan time.
+func (r *scheduleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config scheduleResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Cron.IsNull() == config.Rate.IsNull() && !config.Cron.IsUnknown() && !config.Rate.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cron"),
+			"Invalid Schedule",
+			"Exactly one of cron or rate must be set.",
+		)
+	}
+	if knownString(config.Cron) {
+		if err := validateCron(config.Cron.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cron"),
+				"Invalid Cron Expression",
+				"The cron expression "+config.Cron.ValueString()+" is invalid: "+err.Error(),
+			)
+		}
+	}
+	if knownString(config.Rate) {
+		rate, err := time.ParseDuration(config.Rate.ValueString())
+		if err != nil || rate < time.Minute || rate%time.Minute != 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("rate"),
+				"Invalid Schedule Rate",
+				"The rate must be a whole number of minutes such as \"5m\" or \"1h30m\", got: "+config.Rate.ValueString(),
+			)
+		}
+	}
+	if knownString(config.Timezone) {
+		// LoadLocation also accepts "" for UTC and "Local" for the time
+		// zone of the machine running Terraform, neither of which the API
+		// knows.
+		timezone := config.Timezone.ValueString()
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timezone"),
+				"Invalid Timezone",
+				"The timezone must be an IANA time zone such as \"Europe/London\", got: "+config.Timezone.ValueString(),
+			)
+		}
+	}
+	if knownString(config.Payload) && !json.Valid([]byte(config.Payload.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("payload"),
+			"Invalid Function Payload",
+			"The payload must be a JSON document, for example built with jsonencode().",
+		)
+	}
+}
+
+func (r *scheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan scheduleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.Schedules().Create(ctx, scheduleItem(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating schedule",
+			"Could not create schedule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newScheduleModel(schedule)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *scheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state scheduleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.Schedules().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Schedule",
+			"Could not read schedule ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The schedule was deleted outside Terraform, plan to create it again.
+	if schedule == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state = newScheduleModel(schedule)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *scheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan scheduleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state scheduleResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item := scheduleItem(plan)
+	item.ID = state.ID.ValueString()
+	schedule, err := r.client.Schedules().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating schedule",
+			"Could not update schedule, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan = newScheduleModel(schedule)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *scheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state scheduleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Schedules().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting schedule",
+			"Could not delete schedule, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *scheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *scheduleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func scheduleItem(plan scheduleResourceModel) coderforge.Schedule {
+	return coderforge.Schedule{
+		Name:       plan.Name.ValueString(),
+		Cron:       plan.Cron.ValueString(),
+		Rate:       plan.Rate.ValueString(),
+		Timezone:   plan.Timezone.ValueString(),
+		FunctionId: plan.FunctionId.ValueString(),
+		Payload:    plan.Payload.ValueString(),
+		Enabled:    plan.Enabled.ValueBool(),
+	}
+}
+
+func newScheduleModel(schedule *coderforge.Schedule) scheduleResourceModel {
+	return scheduleResourceModel{
+		ID:         types.StringValue(schedule.ID),
+		Name:       types.StringValue(schedule.Name),
+		Cron:       stringValueOrNull(schedule.Cron),
+		Rate:       stringValueOrNull(schedule.Rate),
+		Timezone:   types.StringValue(schedule.Timezone),
+		FunctionId: types.StringValue(schedule.FunctionId),
+		Payload:    stringValueOrNull(schedule.Payload),
+		Enabled:    types.BoolValue(schedule.Enabled),
+	}
+}
+
+// knownString reports whether value is set and known.
+func knownString(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccScheduleResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "schedule"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+resource "coderforge_schedule" "nightly" {
+  name        = "nightly-report"
+  cron        = "30 2 * * MON-FRI"
+  timezone    = "Europe/London"
+  function_id = coderforge_function.test.id
+  payload     = jsonencode({ report = "daily" })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_schedule.nightly", "id"),
+					resource.TestCheckResourceAttr("coderforge_schedule.nightly", "cron", "30 2 * * MON-FRI"),
+					resource.TestCheckResourceAttr("coderforge_schedule.nightly", "timezone", "Europe/London"),
+					resource.TestCheckResourceAttr("coderforge_schedule.nightly", "enabled", "true"),
+					resource.TestCheckResourceAttrPair("coderforge_schedule.nightly", "function_id", "coderforge_function.test", "id"),
+					testAccCheckItemField(server, "coderforge_schedule.nightly", "type", "schedule"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_schedule.nightly",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Switch to a rate, disable it and fall back to UTC.
+			{
+				Config: testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 180) + `
+resource "coderforge_schedule" "nightly" {
+  name        = "nightly-report"
+  rate        = "15m"
+  function_id = coderforge_function.test.id
+  enabled     = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("coderforge_schedule.nightly", "cron"),
+					resource.TestCheckResourceAttr("coderforge_schedule.nightly", "rate", "15m"),
+					resource.TestCheckResourceAttr("coderforge_schedule.nightly", "timezone", "UTC"),
+					resource.TestCheckResourceAttr("coderforge_schedule.nightly", "enabled", "false"),
+					testAccCheckItemField(server, "coderforge_schedule.nightly", "enabled", false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccScheduleResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		schedule string
+		err      string
+	}{
+		"bad cron":       {`cron = "61 * * * *"`, `Invalid Cron Expression`},
+		"both":           {"cron = \"@daily\"\n  rate = \"5m\"", `Exactly one of cron or rate`},
+		"neither":        {``, `Exactly one of cron or rate`},
+		"bad rate":       {`rate = "90s"`, `whole number of minutes`},
+		"bad timezone":   {"cron = \"@daily\"\n  timezone = \"Mars/Olympus\"", `IANA time zone`},
+		"local timezone": {"cron = \"@daily\"\n  timezone = \"Local\"", `IANA time zone`},
+		"empty timezone": {"cron = \"@daily\"\n  timezone = \"\"", `IANA time zone`},
+		"bad payload":    {"cron = \"@daily\"\n  payload = \"{\"", `must be a JSON document`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_schedule" "test" {
+  name        = "test"
+  function_id = "function-1"
+  ` + tc.schedule + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,420 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &secretResource{}
+	_ resource.ResourceWithConfigure      = &secretResource{}
+	_ resource.ResourceWithImportState    = &secretResource{}
+	_ resource.ResourceWithModifyPlan     = &secretResource{}
+	_ resource.ResourceWithValidateConfig = &secretResource{}
+)
+
+// envVarPattern matches the names of environment variables.
+var envVarPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func NewSecretResource() resource.Resource {
+	return &secretResource{}
+}
+
+// secretResourceModel never holds the value of the secret. Any attribute
+// set in the configuration is also written to state, and this version of
+// the plugin framework has no write-only attributes, so the value is read
+// from the environment variable or file the configuration names instead,
+// and only a salted hash of it is kept to detect changes.
+type secretResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	ValueEnv    types.String `tfsdk:"value_env"`
+	ValueFile   types.String `tfsdk:"value_file"`
+	ValueHash   types.String `tfsdk:"value_hash"`
+	Rotation    types.Int64  `tfsdk:"rotation"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+type secretResource struct {
+	client *coderforge.Client
+}
+
+func (r *secretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+func (r *secretResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value_env": schema.StringAttribute{
+				Optional: true,
+			},
+			"value_file": schema.StringAttribute{
+				Optional: true,
+			},
+			// value_hash is the hex encoded HMAC-SHA256 of the value's
+			// SHA-256 hash, keyed with a random salt kept in private state.
+			"value_hash": schema.StringAttribute{
+				Computed: true,
+			},
+			// Increasing rotation writes the value again even if it did not
+			// change.
+			"rotation": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the value has exactly one source.
+func (r *secretResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config secretResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ValueEnv.IsNull() == config.ValueFile.IsNull() && !config.ValueEnv.IsUnknown() && !config.ValueFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value_env"),
+			"Invalid Secret",
+			"Exactly one of value_env or value_file must be set.",
+		)
+	}
+	if knownString(config.ValueEnv) && !envVarPattern.MatchString(config.ValueEnv.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value_env"),
+			"Invalid Environment Variable Name",
+			"The value_env must be the name of an environment variable, got: "+config.ValueEnv.ValueString(),
+		)
+	}
+	if !config.Rotation.IsNull() && !config.Rotation.IsUnknown() && config.Rotation.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotation"),
+			"Invalid Secret Rotation",
+			"The rotation counter must be at least 0.",
+		)
+	}
+}
+
+// ModifyPlan reads the value at plan time and plans its hash, so changing
+// the value shows up as a change of value_hash. The hash of a new secret is
+// only known once Create has picked its salt.
+func (r *secretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan secretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	salt, diags := getSecretSalt(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	valueHash := types.StringUnknown()
+	if !plan.ValueEnv.IsUnknown() && !plan.ValueFile.IsUnknown() {
+		value, err := secretValue(plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Secret Value", err.Error())
+			return
+		}
+		if salt != "" {
+			valueHash = types.StringValue(secretHash(salt, secretSha256(value)))
+		}
+	}
+	diags = resp.Plan.SetAttribute(ctx, path.Root("value_hash"), valueHash)
+	resp.Diagnostics.Append(diags...)
+
+	// The framework only marks last_updated unknown for changes to the
+	// configuration, not for a new value at the same source.
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var stateHash types.String
+	diags = req.State.GetAttribute(ctx, path.Root("value_hash"), &stateHash)
+	resp.Diagnostics.Append(diags...)
+	if !valueHash.Equal(stateHash) {
+		diags = resp.Plan.SetAttribute(ctx, path.Root("last_updated"), types.StringUnknown())
+		resp.Diagnostics.Append(diags...)
+	}
+}
+
+func (r *secretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan secretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	salt, diags := ensureSecretSalt(ctx, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := secretItem(plan, salt)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Secret Value", err.Error())
+		return
+	}
+	secret, err := r.client.Secrets().Create(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating secret",
+			"Could not create secret, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(secret.ID)
+	plan.ValueHash = types.StringValue(secretHash(salt, secret.ValueSha256))
+	plan.Rotation = types.Int64Value(secret.Rotation)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *secretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state secretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, err := r.client.Secrets().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Secret",
+			"Could not read secret ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The secret was deleted outside Terraform, plan to create it again.
+	if secret == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// An imported secret gets its salt here.
+	salt, diags := ensureSecretSalt(ctx, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A value changed outside Terraform has another hash, so the next plan
+	// writes the configured value again.
+	state.ID = types.StringValue(secret.ID)
+	state.Name = types.StringValue(secret.Name)
+	state.ValueHash = types.StringValue(secretHash(salt, secret.ValueSha256))
+	state.Rotation = types.Int64Value(secret.Rotation)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *secretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan secretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state secretResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	salt, diags := ensureSecretSalt(ctx, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, err := secretItem(plan, salt)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Secret Value", err.Error())
+		return
+	}
+	item.ID = state.ID.ValueString()
+	secret, err := r.client.Secrets().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating secret",
+			"Could not update secret, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(secret.ID)
+	plan.ValueHash = types.StringValue(secretHash(salt, secret.ValueSha256))
+	plan.Rotation = types.Int64Value(secret.Rotation)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *secretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state secretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Secrets().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting secret",
+			"Could not delete secret, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *secretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *secretResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// secretItem returns the API representation of a planned secret, with the
+// value read from its source. It fails if the value changed since the plan.
+func secretItem(plan secretResourceModel, salt string) (coderforge.Secret, error) {
+	value, err := secretValue(plan)
+	if err != nil {
+		return coderforge.Secret{}, err
+	}
+	if knownString(plan.ValueHash) && plan.ValueHash.ValueString() != secretHash(salt, secretSha256(value)) {
+		return coderforge.Secret{}, fmt.Errorf("the value of secret %s changed since the plan was made, plan again", plan.Name.ValueString())
+	}
+	return coderforge.Secret{
+		Name:     plan.Name.ValueString(),
+		Value:    value,
+		Rotation: plan.Rotation.ValueInt64(),
+	}, nil
+}
+
+// secretValue reads the value of a secret from the environment variable or
+// file named in plan. A single trailing newline is dropped from files.
+func secretValue(plan secretResourceModel) (string, error) {
+	if !plan.ValueEnv.IsNull() {
+		name := plan.ValueEnv.ValueString()
+		value := os.Getenv(name)
+		if value == "" {
+			return "", fmt.Errorf("the environment variable %s holding the value of secret %s is not set", name, plan.Name.ValueString())
+		}
+		return value, nil
+	}
+
+	b, err := os.ReadFile(plan.ValueFile.ValueString())
+	if err != nil {
+		return "", fmt.Errorf("could not read the value of secret %s: %w", plan.Name.ValueString(), err)
+	}
+	value := strings.TrimSuffix(strings.TrimSuffix(string(b), "\n"), "\r")
+	if value == "" {
+		return "", fmt.Errorf("the file %s holding the value of secret %s is empty", plan.ValueFile.ValueString(), plan.Name.ValueString())
+	}
+	return value, nil
+}
+
+// secretSha256 returns the hex encoded SHA-256 hash of a secret value, as
+// the API reports it.
+func secretSha256(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+// secretHash returns the value_hash of a secret from the SHA-256 hash of its
+// value. Keying it with a random salt per resource means the state neither
+// shows which secrets share a value nor can be matched against precomputed
+// hashes of common values.
+func secretHash(salt string, valueSha256 string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(valueSha256))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ensureSecretSalt returns the salt of the value hash kept in private state,
+// storing a new random one if there is none.
+func ensureSecretSalt(ctx context.Context, private privateState) (string, diag.Diagnostics) {
+	salt, diags := getSecretSalt(ctx, private)
+	if diags.HasError() || salt != "" {
+		return salt, diags
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		diags.AddError("Error Generating Secret Salt", err.Error())
+		return "", diags
+	}
+	salt = hex.EncodeToString(b)
+	diags.Append(setSecretSalt(ctx, private, salt)...)
+	return salt, diags
+}
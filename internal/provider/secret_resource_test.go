@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSecretResource(t *testing.T) {
+	server := testAccFakeServer(t)
+	t.Setenv("TF_ACC_DB_PASSWORD", "hunter2")
+
+	var secretID, firstHash string
+	config := func(rotation int) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_secret" "db" {
+  name      = "db-password"
+  value_env = "TF_ACC_DB_PASSWORD"
+  rotation  = %d
+}
+
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/hello:1"
+  }
+  secrets = {
+    DB_PASSWORD = coderforge_secret.db.name
+  }
+}
+`, rotation)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "secret"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_secret.db", "id"),
+					resource.TestCheckResourceAttrSet("coderforge_secret.db", "value_hash"),
+					resource.TestCheckResourceAttr("coderforge_secret.db", "rotation", "0"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "secrets.DB_PASSWORD", "db-password"),
+					testAccCheckItemField(server, "coderforge_secret.db", "valueSha256", secretSha256("hunter2")),
+					testAccCheckStateExcludes("hunter2"),
+					testAccCheckStateExcludes(secretSha256("hunter2")),
+					testAccCaptureID("coderforge_secret.db", &secretID),
+					testAccCaptureAttr("coderforge_secret.db", "value_hash", &firstHash),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_secret.db",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "value_env", "value_hash"},
+			},
+			// A new value is written in place.
+			{
+				PreConfig: func() { t.Setenv("TF_ACC_DB_PASSWORD", "correct horse") },
+				Config:    config(0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("coderforge_secret.db", "value_hash", func(value string) error {
+						if value == firstHash {
+							return fmt.Errorf("expected value_hash to change from %s", firstHash)
+						}
+						return nil
+					}),
+					testAccCheckItemField(server, "coderforge_secret.db", "valueSha256", secretSha256("correct horse")),
+					testAccCheckStateExcludes("correct horse"),
+					testAccCheckStateExcludes(secretSha256("correct horse")),
+				),
+			},
+			// A value changed in the console is written again.
+			{
+				PreConfig: func() {
+					if err := server.SetResourceField(secretID, "valueSha256", secretSha256("changed")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config(0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckItemField(server, "coderforge_secret.db", "valueSha256", secretSha256("correct horse")),
+				),
+			},
+			// Increasing the rotation counter writes the same value again.
+			{
+				Config: config(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_secret.db", "rotation", "1"),
+					testAccCheckItemField(server, "coderforge_secret.db", "rotation", float64(1)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_file(t *testing.T) {
+	server := testAccFakeServer(t)
+	file := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(file, []byte("s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "secret"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_secret" "api" {
+  name       = "api-key"
+  value_file = %[1]q
+}
+
+resource "coderforge_secret" "copy" {
+  name       = "api-key-copy"
+  value_file = %[1]q
+}
+`, file),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckItemField(server, "coderforge_secret.api", "valueSha256", secretSha256("s3cr3t")),
+					testAccCheckStateExcludes("s3cr3t"),
+					testAccCheckStateExcludes(secretSha256("s3cr3t")),
+					// Each secret has its own salt, so equal values have
+					// different hashes.
+					resource.TestCheckResourceAttrSet("coderforge_secret.api", "value_hash"),
+					func(s *terraform.State) error {
+						api := s.RootModule().Resources["coderforge_secret.api"].Primary.Attributes["value_hash"]
+						other := s.RootModule().Resources["coderforge_secret.copy"].Primary.Attributes["value_hash"]
+						if api == other {
+							return fmt.Errorf("expected secrets with the same value to have different hashes, got %s", api)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccSecretResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		secret string
+		err    string
+	}{
+		"no source":      {``, `Exactly one of value_env or value_file`},
+		"both sources":   {"value_env = \"A\"\n  value_file = \"/tmp/a\"", `Exactly one of value_env or value_file`},
+		"bad env name":   {`value_env = "DB-PASSWORD"`, `Invalid Environment Variable Name`},
+		"unset env":      {`value_env = "TF_ACC_UNSET_SECRET"`, `is not set`},
+		"missing file":   {`value_file = "/nonexistent/secret"`, `could not read the value`},
+		"negative count": {"value_env = \"A\"\n  rotation = -1", `Invalid Secret Rotation`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_secret" "test" {
+  name = "test"
+  ` + tc.secret + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccFunctionResource_secretValidation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		secrets string
+		err     string
+	}{
+		"bad env name":   {`{ "DB-PASSWORD" = "db-password" }`, `Invalid Environment Variable Name`},
+		"unknown secret": {`{ DB_PASSWORD = "db-password" }`, `secret db-password not found`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+  }
+  secrets = ` + tc.secrets + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+// testAccCheckStateExcludes checks that no attribute in state contains
+// value.
+func testAccCheckStateExcludes(value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for name, rs := range s.RootModule().Resources {
+			for key, attribute := range rs.Primary.Attributes {
+				if strings.Contains(attribute, value) {
+					return fmt.Errorf("attribute %s of %s contains the secret value", key, name)
+				}
+			}
+		}
+		return nil
+	}
+}
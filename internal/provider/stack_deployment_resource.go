@@ -0,0 +1,403 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource              = &stackDeploymentResource{}
+	_ resource.ResourceWithConfigure = &stackDeploymentResource{}
+)
+
+const (
+	defaultStackDeploymentCreateTimeout = 30 * time.Minute
+	defaultStackDeploymentReadTimeout   = 5 * time.Minute
+	defaultStackDeploymentUpdateTimeout = 30 * time.Minute
+	defaultStackDeploymentDeleteTimeout = 20 * time.Minute
+)
+
+func NewStackDeploymentResource() resource.Resource {
+	return &stackDeploymentResource{}
+}
+
+// stackDeploymentResourceModel deploys many functions with one request per
+// operation. Functions are keyed by function name.
+type stackDeploymentResourceModel struct {
+	ID          types.String                  `tfsdk:"id"`
+	Functions   map[string]stackFunctionModel `tfsdk:"functions"`
+	LastUpdated types.String                  `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value                `tfsdk:"timeouts"`
+}
+
+type stackFunctionModel struct {
+	ID         types.String       `tfsdk:"id"`
+	Code       *functionCodeModel `tfsdk:"code"`
+	Timeout    types.Int64        `tfsdk:"timeout"`
+	MaxRamSize types.String       `tfsdk:"max_ram_size"`
+}
+
+type stackDeploymentResource struct {
+	client *coderforge.Client
+}
+
+func (r *stackDeploymentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_stack_deployment"
+}
+
+func (r *stackDeploymentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+			"functions": schema.MapNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+							PlanModifiers: []planmodifier.String{
+								stringplanmodifier.UseStateForUnknown(),
+							},
+						},
+						"code": schema.SingleNestedAttribute{
+							Required: true,
+							Attributes: map[string]schema.Attribute{
+								"package_type": schema.StringAttribute{
+									Required: true,
+								},
+								"image_uri": schema.StringAttribute{
+									Optional: true,
+								},
+							},
+						},
+						"timeout": schema.Int64Attribute{
+							Optional: true,
+						},
+						"max_ram_size": schema.StringAttribute{
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Create deploys every function of the plan in a single request.
+func (r *stackDeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan stackDeploymentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultStackDeploymentCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var resourceItems []coderforge.ResourceItem
+	for _, name := range sortedKeys(plan.Functions) {
+		resourceItems = append(resourceItems, stackFunctionItem(name, plan.Functions[name]))
+	}
+
+	resourceItemsRes, err := r.client.Functions().CreateAll(ctx, resourceItems)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating stack deployment",
+			"Could not create stack deployment, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(r.deploymentID())
+	plan.Functions = stackFunctionModels(resourceItemsRes)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes every function of the deployment with a single request.
+// Functions deleted outside Terraform are dropped, so the next plan deploys
+// them again.
+func (r *stackDeploymentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state stackDeploymentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultStackDeploymentReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	ids := stackFunctionIDs(state.Functions)
+	if len(ids) == 0 {
+		return
+	}
+
+	resourceItemsRes, err := r.client.Functions().GetAll(ctx, ids)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Stack Deployment",
+			"Could not read stack deployment "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Functions = stackFunctionModels(resourceItemsRes)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update deletes, updates and creates functions with at most one request
+// each, in that order. The API applies each request atomically, but not the
+// three together: when one fails, the functions already deleted or updated
+// are saved to state with the error, so the next apply only retries what is
+// left instead of planning against functions that are gone.
+func (r *stackDeploymentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan stackDeploymentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state stackDeploymentResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultStackDeploymentUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var created, updated []coderforge.ResourceItem
+	for _, name := range sortedKeys(plan.Functions) {
+		resourceItem := stackFunctionItem(name, plan.Functions[name])
+		if prior, ok := state.Functions[name]; ok {
+			resourceItem.ID = prior.ID.ValueString()
+			updated = append(updated, resourceItem)
+		} else {
+			created = append(created, resourceItem)
+		}
+	}
+	var deleted []string
+	applied := make(map[string]stackFunctionModel, len(state.Functions))
+	for _, name := range sortedKeys(state.Functions) {
+		if _, ok := plan.Functions[name]; !ok {
+			deleted = append(deleted, state.Functions[name].ID.ValueString())
+		} else {
+			applied[name] = state.Functions[name]
+		}
+	}
+
+	// saveProgress records the functions changed so far when a later
+	// request fails.
+	saveProgress := func() {
+		state.Functions = applied
+		diags = resp.State.Set(ctx, state)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if len(deleted) > 0 {
+		if err := r.client.Functions().DeleteAll(ctx, deleted); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating stack deployment",
+				"Could not delete removed functions, unexpected error: "+err.Error(),
+			)
+			return
+		}
+	}
+	if len(updated) > 0 {
+		updatedRes, err := r.client.Functions().UpdateAll(ctx, updated)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating stack deployment",
+				"Could not update functions, unexpected error: "+err.Error(),
+			)
+			saveProgress()
+			return
+		}
+		maps.Copy(applied, stackFunctionModels(updatedRes))
+	}
+	if len(created) > 0 {
+		createdRes, err := r.client.Functions().CreateAll(ctx, created)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating stack deployment",
+				"Could not create added functions, unexpected error: "+err.Error(),
+			)
+			saveProgress()
+			return
+		}
+		maps.Copy(applied, stackFunctionModels(createdRes))
+	}
+
+	plan.ID = types.StringValue(r.deploymentID())
+	plan.Functions = applied
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes every function of the deployment in a single request.
+func (r *stackDeploymentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state stackDeploymentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultStackDeploymentDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	ids := stackFunctionIDs(state.Functions)
+	if len(ids) == 0 {
+		return
+	}
+
+	err := r.client.Functions().DeleteAll(ctx, ids)
+	if coderforge.IsNotFound(err) {
+		// Some functions are already gone. Delete the rest.
+		var remaining []coderforge.ResourceItem
+		remaining, err = r.client.Functions().GetAll(ctx, ids)
+		if err == nil && len(remaining) > 0 {
+			ids = nil
+			for _, resourceItem := range remaining {
+				ids = append(ids, resourceItem.ID)
+			}
+			err = r.client.Functions().DeleteAll(ctx, ids)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting stack deployment",
+			"Could not delete stack deployment, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *stackDeploymentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// deploymentID identifies the deployment by the stack it deploys to.
+func (r *stackDeploymentResource) deploymentID() string {
+	if r.client.StackId != "" {
+		return r.client.StackId
+	}
+	return r.client.CloudSpace
+}
+
+func stackFunctionItem(name string, function stackFunctionModel) coderforge.ResourceItem {
+	return coderforge.ResourceItem{
+		Type:         coderforge.ResourceTypeFunction,
+		FunctionName: name,
+		Code: coderforge.Code{
+			PackageType: function.Code.PackageType.ValueString(),
+			ImageUri:    function.Code.ImageUri.ValueString(),
+		},
+		Timeout:    function.Timeout.ValueInt64(),
+		MaxRamSize: function.MaxRamSize.ValueString(),
+	}
+}
+
+func stackFunctionModels(resourceItems []coderforge.ResourceItem) map[string]stackFunctionModel {
+	functions := make(map[string]stackFunctionModel, len(resourceItems))
+	for _, resourceItem := range resourceItems {
+		functions[resourceItem.FunctionName] = stackFunctionModel{
+			ID: types.StringValue(resourceItem.ID),
+			Code: &functionCodeModel{
+				PackageType: types.StringValue(resourceItem.Code.PackageType),
+				ImageUri:    stringValueOrNull(resourceItem.Code.ImageUri),
+			},
+			Timeout:    int64ValueOrNull(resourceItem.Timeout),
+			MaxRamSize: stringValueOrNull(resourceItem.MaxRamSize),
+		}
+	}
+	return functions
+}
+
+func stackFunctionIDs(functions map[string]stackFunctionModel) []string {
+	var ids []string
+	for _, name := range sortedKeys(functions) {
+		ids = append(ids, functions[name].ID.ValueString())
+	}
+	return ids
+}
+
+// sortedKeys returns the keys of m in order, so requests are deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
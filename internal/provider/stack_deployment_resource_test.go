@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-coderforge/internal/fakeserver"
+)
+
+func TestAccStackDeploymentResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var usersID string
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccStackDeploymentResourceConfig(map[string]string{
+					"users":  "users:1",
+					"orders": "orders:1",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "id", "stack-test"),
+					resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "functions.%", "2"),
+					resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "functions.users.code.image_uri", "docker.coderforge.org/users:1"),
+					resource.TestCheckResourceAttrSet("coderforge_stack_deployment.test", "functions.orders.id"),
+					testAccCaptureAttr("coderforge_stack_deployment.test", "functions.users.id", &usersID),
+					testAccCheckRequestCount(server, "POST ", 1),
+				),
+			},
+			// Update, add and remove functions in the same apply.
+			{
+				Config: testAccProviderConfig(server) + testAccStackDeploymentResourceConfig(map[string]string{
+					"users":    "users:2",
+					"payments": "payments:1",
+				}),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "functions.%", "2"),
+					resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "functions.users.code.image_uri", "docker.coderforge.org/users:2"),
+					resource.TestCheckResourceAttrPtr("coderforge_stack_deployment.test", "functions.users.id", &usersID),
+					resource.TestCheckResourceAttrSet("coderforge_stack_deployment.test", "functions.payments.id"),
+					resource.TestCheckNoResourceAttr("coderforge_stack_deployment.test", "functions.orders.id"),
+					testAccCheckRequestCount(server, "POST ", 2),
+					testAccCheckRequestCount(server, "PUT ", 1),
+					testAccCheckRequestCount(server, "DELETE ", 1),
+				),
+			},
+			// A function deleted outside Terraform is deployed again.
+			{
+				PreConfig: func() {
+					server.RemoveResource(usersID)
+				},
+				Config: testAccProviderConfig(server) + testAccStackDeploymentResourceConfig(map[string]string{
+					"users":    "users:2",
+					"payments": "payments:1",
+				}),
+				Check: func(s *terraform.State) error {
+					id := s.RootModule().Resources["coderforge_stack_deployment.test"].Primary.Attributes["functions.users.id"]
+					if id == usersID {
+						return fmt.Errorf("expected a new function, got the deleted ID %s", id)
+					}
+					if _, ok := server.Resource(id); !ok {
+						return fmt.Errorf("function %s not found in the API", id)
+					}
+					return nil
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccStackDeploymentResource_partialUpdate(t *testing.T) {
+	server := testAccFakeServer(t)
+	config := testAccProviderConfig(server) + testAccStackDeploymentResourceConfig(map[string]string{
+		"users":    "users:2",
+		"payments": "payments:1",
+	})
+
+	var usersID string
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccStackDeploymentResourceConfig(map[string]string{
+					"users":  "users:1",
+					"orders": "orders:1",
+				}),
+				Check: testAccCaptureAttr("coderforge_stack_deployment.test", "functions.users.id", &usersID),
+			},
+			// The delete and update are applied before the create fails...
+			{
+				PreConfig: func() {
+					server.RejectNextCreates(1)
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Could not create added functions`),
+			},
+			// ...and the next apply only creates the missing function.
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "functions.%", "2"),
+					resource.TestCheckResourceAttrPtr("coderforge_stack_deployment.test", "functions.users.id", &usersID),
+					resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "functions.users.code.image_uri", "docker.coderforge.org/users:2"),
+					resource.TestCheckResourceAttrSet("coderforge_stack_deployment.test", "functions.payments.id"),
+					testAccCheckFunctionCount(server, 2),
+					testAccCheckRequestCount(server, "DELETE ", 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStackDeploymentResource_empty(t *testing.T) {
+	server := testAccFakeServer(t)
+	config := testAccProviderConfig(server) + testAccFunctionResourceConfig("hello:1", 30) +
+		testAccStackDeploymentResourceConfig(map[string]string{})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckFunctionDestroy(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "functions.%", "0"),
+			},
+			// Refreshing a stack without functions does not list every
+			// function of the cloud space.
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("coderforge_stack_deployment.test", "functions.%", "0"),
+					testAccCheckFunctionCount(server, 1),
+				),
+			},
+		},
+	})
+}
+
+func testAccStackDeploymentResourceConfig(images map[string]string) string {
+	var functions strings.Builder
+	for _, name := range sortedKeys(images) {
+		fmt.Fprintf(&functions, `
+    %[1]s = {
+      code = {
+        package_type = "container_image"
+        image_uri    = "docker.coderforge.org/%[2]s"
+      }
+      max_ram_size = "256MB"
+    }`, name, images[name])
+	}
+	return fmt.Sprintf(`
+resource "coderforge_stack_deployment" "test" {
+  functions = {%s
+  }
+}
+`, functions.String())
+}
+
+// testAccCaptureAttr stores an attribute of the named resource in value.
+func testAccCaptureAttr(name string, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+// testAccCheckRequestCount checks how many requests starting with prefix,
+// such as "POST ", the fake API has served so far.
+func testAccCheckRequestCount(server *fakeserver.Server, prefix string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var n int
+		for _, request := range server.Requests() {
+			if strings.HasPrefix(request, prefix) {
+				n++
+			}
+		}
+		if n != expected {
+			return fmt.Errorf("expected %d %srequests, got %d", expected, prefix, n)
+		}
+		return nil
+	}
+}
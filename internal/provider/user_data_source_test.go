@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUserDataSource(t *testing.T) {
+	server := testAccFakeServer(t)
+	ownerID := server.AddUser("owner@example.com", "Owner")
+	janeID := server.AddUser("jane@example.com", "Jane Doe", "developers", "oncall")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "coderforge_user" "jane" {
+  email = "jane@example.com"
+}
+
+data "coderforge_user" "current" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.coderforge_user.jane", "id", janeID),
+					resource.TestCheckResourceAttr("data.coderforge_user.jane", "name", "Jane Doe"),
+					resource.TestCheckResourceAttr("data.coderforge_user.jane", "groups.#", "2"),
+					resource.TestCheckResourceAttr("data.coderforge_user.jane", "groups.1", "oncall"),
+					resource.TestCheckResourceAttr("data.coderforge_user.current", "id", ownerID),
+					resource.TestCheckResourceAttr("data.coderforge_user.current", "email", "owner@example.com"),
+					resource.TestCheckResourceAttr("data.coderforge_user.current", "groups.#", "0"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+data "coderforge_user" "nobody" {
+  email = "nobody@example.com"
+}
+`,
+				ExpectError: regexp.MustCompile(`User Not Found`),
+			},
+		},
+	})
+}
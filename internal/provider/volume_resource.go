@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-coderforge/pkg/coderforge"
+)
+
+var (
+	_ resource.Resource                   = &volumeResource{}
+	_ resource.ResourceWithConfigure      = &volumeResource{}
+	_ resource.ResourceWithImportState    = &volumeResource{}
+	_ resource.ResourceWithValidateConfig = &volumeResource{}
+)
+
+// maxVolumeSizeGb is the size of the largest volume, in GB.
+const maxVolumeSizeGb = 16384
+
+// accessModes are the ways functions can mount a volume.
+var accessModes = []string{coderforge.AccessReadWriteOnce, coderforge.AccessReadWriteMany, coderforge.AccessReadOnlyMany}
+
+func NewVolumeResource() resource.Resource {
+	return &volumeResource{}
+}
+
+type volumeResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	SizeGb      types.Int64  `tfsdk:"size_gb"`
+	Locations   types.List   `tfsdk:"locations"`
+	AccessMode  types.String `tfsdk:"access_mode"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+}
+
+type volumeResource struct {
+	client *coderforge.Client
+}
+
+func (r *volumeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume"
+}
+
+func (r *volumeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// Volumes grow in place. Shrinking a volume replaces it, which
+			// loses its data.
+			"size_gb": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(volumeShrinks, "Shrinking a volume replaces it.", "Shrinking a volume replaces it."),
+				},
+			},
+			"locations": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"access_mode": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(coderforge.AccessReadWriteOnce),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+// volumeShrinks reports whether the planned size is below the size in
+// state.
+func volumeShrinks(_ context.Context, req planmodifier.Int64Request, resp *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.PlanValue.IsUnknown() && req.PlanValue.ValueInt64() < req.StateValue.ValueInt64()
+}
+
+// ValidateConfig checks the size, the locations and the access mode.
+func (r *volumeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config volumeResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if size := config.SizeGb; !size.IsNull() && !size.IsUnknown() && (size.ValueInt64() < 1 || size.ValueInt64() > maxVolumeSizeGb) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("size_gb"),
+			"Invalid Volume Size",
+			fmt.Sprintf("The size_gb must be between 1 and %d, got: %d.", maxVolumeSizeGb, size.ValueInt64()),
+		)
+	}
+	if !config.Locations.IsUnknown() && len(config.Locations.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("locations"),
+			"Missing Volume Location",
+			"A volume must be replicated to at least one location.",
+		)
+	}
+	if knownString(config.AccessMode) && !slices.Contains(accessModes, config.AccessMode.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("access_mode"),
+			"Invalid Access Mode",
+			"The access_mode must be read_write_once, read_write_many or read_only_many, got: "+config.AccessMode.ValueString(),
+		)
+	}
+}
+
+func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan volumeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := volumeItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	volume, err := r.client.Volumes().Create(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating volume",
+			"Could not create volume, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newVolumeModel(ctx, volume)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state volumeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	volume, err := r.client.Volumes().Get(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Volume",
+			"Could not read volume ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The volume was deleted outside Terraform, plan to create it again.
+	if volume == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	lastUpdated := state.LastUpdated
+	state, diags = newVolumeModel(ctx, volume)
+	resp.Diagnostics.Append(diags...)
+	state.LastUpdated = lastUpdated
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *volumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan volumeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	var state volumeResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	item, diags := volumeItem(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	item.ID = state.ID.ValueString()
+	volume, err := r.client.Volumes().Update(ctx, item)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating volume",
+			"Could not update volume, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	plan, diags = newVolumeModel(ctx, volume)
+	resp.Diagnostics.Append(diags...)
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state volumeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.Volumes().Delete(ctx, state.ID.ValueString())
+	if err != nil && !coderforge.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting volume",
+			"Could not delete volume, unexpected error: "+err.Error(),
+		)
+	}
+}
+
+func (r *volumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *volumeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*coderforge.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *coderforge.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func volumeItem(ctx context.Context, plan volumeResourceModel) (coderforge.Volume, diag.Diagnostics) {
+	volume := coderforge.Volume{
+		Name:       plan.Name.ValueString(),
+		SizeGb:     plan.SizeGb.ValueInt64(),
+		AccessMode: plan.AccessMode.ValueString(),
+	}
+	diags := plan.Locations.ElementsAs(ctx, &volume.Locations, false)
+	return volume, diags
+}
+
+func newVolumeModel(ctx context.Context, volume *coderforge.Volume) (volumeResourceModel, diag.Diagnostics) {
+	locations, diags := types.ListValueFrom(ctx, types.StringType, volume.Locations)
+	return volumeResourceModel{
+		ID:         types.StringValue(volume.ID),
+		Name:       types.StringValue(volume.Name),
+		SizeGb:     types.Int64Value(volume.SizeGb),
+		Locations:  locations,
+		AccessMode: types.StringValue(volume.AccessMode),
+	}, diags
+}
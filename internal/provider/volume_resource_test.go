@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccVolumeResource(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	var volumeID string
+	config := func(sizeGb int) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "coderforge_volume" "cache" {
+  name      = "cache"
+  size_gb   = %d
+  locations = ["gbr-1", "gbr-2"]
+}
+
+resource "coderforge_volume" "models" {
+  name        = "models"
+  size_gb     = 50
+  locations   = ["gbr-1", "gbr-2"]
+  access_mode = "read_only_many"
+}
+
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+    image_uri    = "docker.coderforge.org/hello:1"
+  }
+  mounts = [
+    { volume_id = coderforge_volume.cache.id, mount_path = "/var/cache" },
+    { volume_id = coderforge_volume.models.id, mount_path = "/models", read_only = true },
+  ]
+}
+`, sizeGb)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "volume"),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: config(10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("coderforge_volume.cache", "id"),
+					resource.TestCheckResourceAttr("coderforge_volume.cache", "access_mode", "read_write_once"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "mounts.#", "2"),
+					resource.TestCheckResourceAttrPair("coderforge_function.test", "mounts.0.volume_id", "coderforge_volume.cache", "id"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "mounts.0.read_only", "false"),
+					resource.TestCheckResourceAttr("coderforge_function.test", "mounts.1.read_only", "true"),
+					testAccCaptureID("coderforge_volume.cache", &volumeID),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "coderforge_volume.cache",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				ResourceName:            "coderforge_function.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Volumes grow in place.
+			{
+				Config: config(20),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("coderforge_volume.cache", "id", &volumeID),
+					testAccCheckItemField(server, "coderforge_volume.cache", "sizeGb", float64(20)),
+				),
+			},
+			// Shrinking a volume replaces it.
+			{
+				Config: config(5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAttributeChanged("coderforge_volume.cache", "id", &volumeID),
+					resource.TestCheckResourceAttrPair("coderforge_function.test", "mounts.0.volume_id", "coderforge_volume.cache", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVolumeResource_incompatibleMount(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	volumes := testAccProviderConfig(server) + `
+resource "coderforge_volume" "gbr1" {
+  name      = "gbr1"
+  size_gb   = 10
+  locations = ["gbr-1"]
+}
+
+resource "coderforge_volume" "models" {
+  name        = "models"
+  size_gb     = 10
+  locations   = ["gbr-1", "gbr-2"]
+  access_mode = "read_only_many"
+}
+
+resource "coderforge_function" "other" {
+  function_name = "other"
+  code = {
+    package_type = "javascript"
+  }
+}
+`
+	function := func(mounts string) string {
+		return fmt.Sprintf(`
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+  }
+  mounts = %s
+}
+`, mounts)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckDestroy(server, "function", "volume"),
+		Steps: []resource.TestStep{
+			{
+				Config: volumes,
+			},
+			{
+				Config:      volumes + function(`[{ volume_id = coderforge_volume.gbr1.id, mount_path = "/data" }]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Incompatible Volume Location`),
+			},
+			{
+				Config:      volumes + function(`[{ volume_id = coderforge_volume.models.id, mount_path = "/models" }]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Read-Only Volume`),
+			},
+			// The ID of an item that is not a volume is not found.
+			{
+				Config:      volumes + function(`[{ volume_id = coderforge_function.other.id, mount_path = "/data" }]`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Volume Not Found`),
+			},
+		},
+	})
+}
+
+func TestAccVolumeResource_validation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		volume string
+		err    string
+	}{
+		"too small":       {"size_gb = 0\n  locations = [\"gbr-1\"]", `Invalid Volume Size`},
+		"too large":       {"size_gb = 20000\n  locations = [\"gbr-1\"]", `Invalid Volume Size`},
+		"no locations":    {"size_gb = 1\n  locations = []", `Missing Volume Location`},
+		"bad access mode": {"size_gb = 1\n  locations = [\"gbr-1\"]\n  access_mode = \"shared\"", `Invalid Access Mode`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_volume" "test" {
+  name = "test"
+  ` + tc.volume + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccFunctionResource_mountValidation(t *testing.T) {
+	server := testAccFakeServer(t)
+
+	for name, tc := range map[string]struct {
+		mounts string
+		err    string
+	}{
+		"relative path":  {`[{ volume_id = "v", mount_path = "data" }]`, `Invalid Mount Path`},
+		"unclean path":   {`[{ volume_id = "v", mount_path = "/data/" }]`, `Invalid Mount Path`},
+		"root":           {`[{ volume_id = "v", mount_path = "/" }]`, `Invalid Mount Path`},
+		"reserved path":  {`[{ volume_id = "v", mount_path = "/proc/data" }]`, `conflicts with /proc`},
+		"same path":      {`[{ volume_id = "v", mount_path = "/data" }, { volume_id = "w", mount_path = "/data" }]`, `Conflicting Mount Paths`},
+		"nested path":    {`[{ volume_id = "v", mount_path = "/data" }, { volume_id = "w", mount_path = "/data/cache" }]`, `Conflicting Mount Paths`},
+		"unknown volume": {`[{ volume_id = "v", mount_path = "/data" }]`, `Volume Not Found`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + `
+resource "coderforge_function" "test" {
+  function_name = "helloWorld"
+  code = {
+    package_type = "container_image"
+  }
+  mounts = ` + tc.mounts + `
+}
+`,
+						ExpectError: regexp.MustCompile(tc.err),
+					},
+				},
+			})
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	// Embed the IANA time zone database, so schedule timezones validate
+	// on machines without one.
+	_ "time/tzdata"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
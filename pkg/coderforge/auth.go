@@ -0,0 +1,331 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry a cached token is refreshed,
+// so that a request never leaves with a token that expires in flight.
+const tokenExpiryDelta = time.Minute
+
+// TokenSource supplies the bearer token sent with every API request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticTokenSource returns the same token for every request.
+type StaticTokenSource string
+
+func (s StaticTokenSource) Token(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+// FileTokenSource reads the token from a file on every request, so a token
+// rotated on disk by another tool is picked up without restarting Terraform.
+type FileTokenSource struct {
+	Path string
+}
+
+func (s *FileTokenSource) Token(_ context.Context) (string, error) {
+	b, err := os.ReadFile(expandHome(s.Path))
+	if err != nil {
+		return "", fmt.Errorf("reading token file: %w", err)
+	}
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", s.Path)
+	}
+	return token, nil
+}
+
+// CommandTokenSource runs a credentials helper and uses its standard output
+// as the token. Command is passed to "sh -c", so paths containing spaces must
+// be quoted as they would be in a shell. The helper may print either the raw
+// token or a JSON object with "token" and an optional RFC 3339 "expires_at";
+// without an expiry the token is reused for the lifetime of the provider.
+type CommandTokenSource struct {
+	Command string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (s *CommandTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Until(s.expiry) > tokenExpiryDelta) {
+		return s.token, nil
+	}
+
+	if strings.TrimSpace(s.Command) == "" {
+		return "", fmt.Errorf("credentials helper command is empty")
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", s.Command)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running credentials helper %q: %w", s.Command, err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	token, expiry := output, time.Time{}
+	if strings.HasPrefix(output, "{") {
+		var helperRes struct {
+			Token     string    `json:"token"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}
+		if err := json.Unmarshal(out, &helperRes); err != nil {
+			return "", fmt.Errorf("decoding credentials helper output: %w", err)
+		}
+		token, expiry = helperRes.Token, helperRes.ExpiresAt
+	}
+	if token == "" {
+		return "", fmt.Errorf("credentials helper %q returned an empty token", s.Command)
+	}
+
+	s.token, s.expiry = token, expiry
+	return s.token, nil
+}
+
+// ClientCredentialsTokenSource obtains access tokens from an OAuth2 token
+// endpoint with the client credentials grant and refreshes them shortly
+// before they expire.
+type ClientCredentialsTokenSource struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	HTTPClient   *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// tokenResponse is the token endpoint response defined by RFC 6749 section 5.
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (s *ClientCredentialsTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Until(s.expiry) > tokenExpiryDelta) {
+		return s.token, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(s.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.Scopes, " "))
+	}
+
+	tokenRes, err := requestToken(ctx, s.HTTPClient, s.TokenURL, form, func(req *http.Request) {
+		req.SetBasicAuth(url.QueryEscape(s.ClientID), url.QueryEscape(s.ClientSecret))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	s.token = tokenRes.AccessToken
+	s.expiry = time.Time{}
+	if tokenRes.ExpiresIn > 0 {
+		s.expiry = time.Now().Add(time.Duration(tokenRes.ExpiresIn) * time.Second)
+	}
+	return s.token, nil
+}
+
+// Token exchange parameters defined by RFC 8693.
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+	tokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// TokenExchangeTokenSource exchanges an OIDC identity token issued to a CI
+// job for a short-lived CoderForge.org token with the RFC 8693 token exchange
+// grant. The identity token is fetched again on every exchange, so tokens
+// that the CI system rotates on disk keep working for long applies.
+type TokenExchangeTokenSource struct {
+	TokenURL      string
+	Audience      string
+	Role          string
+	IdentityToken TokenSource
+	HTTPClient    *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func (s *TokenExchangeTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Until(s.expiry) > tokenExpiryDelta) {
+		return s.token, nil
+	}
+
+	identityToken, err := s.IdentityToken.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("obtaining CI identity token: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", grantTypeTokenExchange)
+	form.Set("subject_token", identityToken)
+	form.Set("subject_token_type", tokenTypeJWT)
+	form.Set("requested_token_type", tokenTypeAccessToken)
+	if s.Audience != "" {
+		form.Set("audience", s.Audience)
+	}
+	if s.Role != "" {
+		form.Set("role", s.Role)
+	}
+
+	tokenRes, err := requestToken(ctx, s.HTTPClient, s.TokenURL, form, nil)
+	if err != nil {
+		return "", fmt.Errorf("exchanging CI identity token at %s: %w", s.TokenURL, err)
+	}
+
+	s.token = tokenRes.AccessToken
+	s.expiry = time.Time{}
+	if tokenRes.ExpiresIn > 0 {
+		s.expiry = time.Now().Add(time.Duration(tokenRes.ExpiresIn) * time.Second)
+	}
+	return s.token, nil
+}
+
+// GitHubActionsTokenSource requests an OIDC identity token for the running
+// GitHub Actions job. The workflow needs the "id-token: write" permission,
+// which makes GitHub set ACTIONS_ID_TOKEN_REQUEST_URL and
+// ACTIONS_ID_TOKEN_REQUEST_TOKEN.
+type GitHubActionsTokenSource struct {
+	RequestURL   string
+	RequestToken string
+	Audience     string
+	HTTPClient   *http.Client
+}
+
+func (s *GitHubActionsTokenSource) Token(ctx context.Context) (string, error) {
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	requestURL, err := url.Parse(s.RequestURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing ACTIONS_ID_TOKEN_REQUEST_URL: %w", err)
+	}
+	if s.Audience != "" {
+		query := requestURL.Query()
+		query.Set("audience", s.Audience)
+		requestURL.RawQuery = query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.RequestToken)
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("requesting GitHub Actions identity token: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub Actions identity token status: %d, body: %s", res.StatusCode, body)
+	}
+
+	var idTokenRes struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &idTokenRes); err != nil {
+		return "", fmt.Errorf("decoding GitHub Actions identity token: %w", err)
+	}
+	if idTokenRes.Value == "" {
+		return "", fmt.Errorf("GitHub Actions returned an empty identity token")
+	}
+	return idTokenRes.Value, nil
+}
+
+// requestToken posts form to an OAuth2 token endpoint and decodes the
+// response, turning OAuth2 error responses into Go errors.
+func requestToken(ctx context.Context, httpClient *http.Client, tokenURL string, form url.Values, authorize func(*http.Request)) (*tokenResponse, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if authorize != nil {
+		authorize(req)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting token: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading token response: %w", err)
+	}
+
+	tokenRes := tokenResponse{}
+	if err := json.Unmarshal(body, &tokenRes); err != nil && res.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("decoding token response: %w", err)
+	}
+	if tokenRes.Error != "" {
+		return nil, fmt.Errorf("token endpoint returned %s: %s", tokenRes.Error, tokenRes.ErrorDescription)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint status: %d, body: %s", res.StatusCode, body)
+	}
+	if tokenRes.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint returned no access_token")
+	}
+	return &tokenRes, nil
+}
+
+// expandHome replaces a leading "~" in path with the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
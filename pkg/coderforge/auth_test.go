@@ -0,0 +1,315 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newMockOIDCServer starts a token endpoint that accepts the client
+// credentials grant for clientID/clientSecret and issues tokens that live for
+// expiresIn seconds. The returned counter records how many tokens it issued.
+func newMockOIDCServer(t *testing.T, clientID, clientSecret string, expiresIn int64) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var issued int32
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != clientID || secret != clientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client", "error_description": "bad credentials"})
+			return
+		}
+		if r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "unsupported_grant_type"})
+			return
+		}
+		n := atomic.AddInt32(&issued, 1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("access-%d", n),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	})
+
+	return server, &issued
+}
+
+func TestClientCredentialsTokenSource_cachesToken(t *testing.T) {
+	server, issued := newMockOIDCServer(t, "ci", "s3cret", 3600)
+
+	ts := &ClientCredentialsTokenSource{
+		TokenURL:     server.URL + "/oauth/token",
+		ClientID:     "ci",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"deploy"},
+	}
+
+	for i := 0; i < 3; i++ {
+		token, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if token != "access-1" {
+			t.Fatalf("expected access-1, got %s", token)
+		}
+	}
+	if got := atomic.LoadInt32(issued); got != 1 {
+		t.Fatalf("expected 1 token request, got %d", got)
+	}
+}
+
+func TestClientCredentialsTokenSource_refreshesBeforeExpiry(t *testing.T) {
+	// Tokens expiring within tokenExpiryDelta are refreshed on every call.
+	server, issued := newMockOIDCServer(t, "ci", "s3cret", 30)
+
+	ts := &ClientCredentialsTokenSource{
+		TokenURL:     server.URL + "/oauth/token",
+		ClientID:     "ci",
+		ClientSecret: "s3cret",
+	}
+
+	first, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	second, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if first == second {
+		t.Fatalf("expected a refreshed token, got %s twice", first)
+	}
+	if got := atomic.LoadInt32(issued); got != 2 {
+		t.Fatalf("expected 2 token requests, got %d", got)
+	}
+}
+
+func TestClientCredentialsTokenSource_invalidClient(t *testing.T) {
+	server, _ := newMockOIDCServer(t, "ci", "s3cret", 3600)
+
+	ts := &ClientCredentialsTokenSource{
+		TokenURL:     server.URL + "/oauth/token",
+		ClientID:     "ci",
+		ClientSecret: "wrong",
+	}
+
+	_, err := ts.Token(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for invalid client credentials")
+	}
+	if want := "token endpoint returned invalid_client: bad credentials"; err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	ts := &FileTokenSource{Path: tokenFile}
+
+	if err := os.WriteFile(tokenFile, []byte("first\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := ts.Token(context.Background()); err != nil || token != "first" {
+		t.Fatalf("expected first, got %q (%v)", token, err)
+	}
+
+	// A token rotated on disk is used by the next request.
+	if err := os.WriteFile(tokenFile, []byte("second"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := ts.Token(context.Background()); err != nil || token != "second" {
+		t.Fatalf("expected second, got %q (%v)", token, err)
+	}
+
+	if err := os.WriteFile(tokenFile, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Token(context.Background()); err == nil {
+		t.Fatal("expected an error for an empty token file")
+	}
+}
+
+func TestCommandTokenSource(t *testing.T) {
+	ts := &CommandTokenSource{Command: "echo helper-token"}
+
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token != "helper-token" {
+		t.Fatalf("expected helper-token, got %s", token)
+	}
+}
+
+func TestCommandTokenSource_quotedPath(t *testing.T) {
+	helper := filepath.Join(t.TempDir(), "credentials helper")
+	script := "#!/bin/sh\nprintf '{\"token\":\"%s\"}' \"$1\"\n"
+	if err := os.WriteFile(helper, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	ts := &CommandTokenSource{Command: fmt.Sprintf("'%s' 'quoted token'", helper)}
+
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token != "quoted token" {
+		t.Fatalf("expected quoted token, got %s", token)
+	}
+}
+
+func TestClient_sendsBearerToken(t *testing.T) {
+	oidc, _ := newMockOIDCServer(t, "ci", "s3cret", 3600)
+
+	var authorization string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"resourceItems":[{"id":"f-1","type":"function"}]}`))
+	}))
+	t.Cleanup(api.Close)
+
+	client, err := NewClient("test.coderforge.org",
+		WithHostURL(api.URL),
+		WithTokenSource(&ClientCredentialsTokenSource{
+			TokenURL:     oidc.URL + "/oauth/token",
+			ClientID:     "ci",
+			ClientSecret: "s3cret",
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetResource(context.Background(), "f-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if authorization != "Bearer access-1" {
+		t.Fatalf("expected Bearer access-1, got %q", authorization)
+	}
+}
+
+// newTokenExchangeServer starts a stand-in for the CoderForge.org token
+// exchange endpoint that trusts identity tokens equal to subjectToken for the
+// given audience and role.
+func newTokenExchangeServer(t *testing.T, subjectToken, audience, role string) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var exchanged int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.FormValue("grant_type") != grantTypeTokenExchange || r.FormValue("subject_token_type") != tokenTypeJWT {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "unsupported_grant_type"})
+			return
+		}
+		if r.FormValue("subject_token") != subjectToken || r.FormValue("audience") != audience || r.FormValue("role") != role {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant", "error_description": "identity token not trusted"})
+			return
+		}
+		n := atomic.AddInt32(&exchanged, 1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      fmt.Sprintf("exchanged-%d", n),
+			"issued_token_type": tokenTypeAccessToken,
+			"token_type":        "Bearer",
+			"expires_in":        900,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &exchanged
+}
+
+func TestTokenExchangeTokenSource_fromFile(t *testing.T) {
+	server, exchanged := newTokenExchangeServer(t, "ci-jwt", "coderforge", "deployer")
+
+	identityTokenFile := filepath.Join(t.TempDir(), "id_token")
+	if err := os.WriteFile(identityTokenFile, []byte("ci-jwt\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := &TokenExchangeTokenSource{
+		TokenURL:      server.URL,
+		Audience:      "coderforge",
+		Role:          "deployer",
+		IdentityToken: &FileTokenSource{Path: identityTokenFile},
+	}
+
+	for i := 0; i < 2; i++ {
+		token, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if token != "exchanged-1" {
+			t.Fatalf("expected exchanged-1, got %s", token)
+		}
+	}
+	if got := atomic.LoadInt32(exchanged); got != 1 {
+		t.Fatalf("expected 1 exchange, got %d", got)
+	}
+}
+
+func TestTokenExchangeTokenSource_untrustedRole(t *testing.T) {
+	server, _ := newTokenExchangeServer(t, "ci-jwt", "coderforge", "deployer")
+
+	ts := &TokenExchangeTokenSource{
+		TokenURL:      server.URL,
+		Audience:      "coderforge",
+		Role:          "admin",
+		IdentityToken: StaticTokenSource("ci-jwt"),
+	}
+
+	_, err := ts.Token(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for an untrusted role")
+	}
+	if !strings.Contains(err.Error(), server.URL) {
+		t.Fatalf("expected the error to name the token URL, got %s", err)
+	}
+}
+
+func TestTokenExchangeTokenSource_gitHubActions(t *testing.T) {
+	server, _ := newTokenExchangeServer(t, "gha-jwt", "coderforge", "deployer")
+
+	actions := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" || r.URL.Query().Get("audience") != "coderforge" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"value": "gha-jwt"})
+	}))
+	t.Cleanup(actions.Close)
+
+	ts := &TokenExchangeTokenSource{
+		TokenURL: server.URL,
+		Audience: "coderforge",
+		Role:     "deployer",
+		IdentityToken: &GitHubActionsTokenSource{
+			RequestURL:   actions.URL + "/token?api-version=2.0",
+			RequestToken: "request-token",
+			Audience:     "coderforge",
+		},
+	}
+
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token != "exchanged-1" {
+		t.Fatalf("expected exchanged-1, got %s", token)
+	}
+}
@@ -0,0 +1,124 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// WithBulkReads makes the client answer single-item reads from one list
+// request per item type, made by the first read and kept for the lifetime of
+// the client. Refreshing a stack of many resources then costs one request
+// instead of one per resource. Items written through the client are read
+// from the API again.
+func WithBulkReads() Option {
+	return func(c *Client) {
+		c.bulk = &bulkReader{snapshots: map[string]*bulkSnapshot{}}
+	}
+}
+
+type bulkReader struct {
+	mu        sync.Mutex
+	snapshots map[string]*bulkSnapshot
+}
+
+// bulkSnapshot is the list of every item of one type in the client's stack.
+type bulkSnapshot struct {
+	mu sync.Mutex
+	// loading is closed when the load in flight, if any, completes.
+	loading chan struct{}
+	loaded  bool
+	items   map[string]json.RawMessage
+	err     error
+	// written holds the IDs of items written through the client, which the
+	// snapshot may predate however it was loaded.
+	written map[string]bool
+}
+
+// bulkGet returns the item from the snapshot of its type, loading the
+// snapshot on first use. It reports false when bulk reads are disabled, the
+// snapshot could not be loaded or the item is not in it or was written since,
+// in which case the caller reads the item directly.
+func (c *Client) bulkGet(ctx context.Context, itemType string, id string) (json.RawMessage, bool) {
+	if c.bulk == nil {
+		return nil, false
+	}
+	snapshot := c.bulk.snapshot(itemType)
+
+	for {
+		snapshot.mu.Lock()
+		if snapshot.loaded {
+			defer snapshot.mu.Unlock()
+			if snapshot.err != nil || snapshot.written[id] {
+				return nil, false
+			}
+			rawItem, ok := snapshot.items[id]
+			return rawItem, ok
+		}
+		if snapshot.loading == nil {
+			snapshot.loading = make(chan struct{})
+			// The snapshot is shared by every read of the type, so the load
+			// outlives the read that starts it.
+			go c.loadSnapshot(context.WithoutCancel(ctx), itemType, snapshot)
+		}
+		loading := snapshot.loading
+		snapshot.mu.Unlock()
+
+		select {
+		case <-loading:
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+// loadSnapshot lists the items of itemType into snapshot. A load that timed
+// out is not kept, so the next read tries again.
+func (c *Client) loadSnapshot(ctx context.Context, itemType string, snapshot *bulkSnapshot) {
+	rawItems, err := c.list(ctx, ListFilter{Type: itemType, StackId: c.StackId})
+	items := make(map[string]json.RawMessage, len(rawItems))
+	for _, rawItem := range ofType(rawItems, itemType) {
+		items[itemID(rawItem)] = rawItem
+	}
+
+	snapshot.mu.Lock()
+	defer snapshot.mu.Unlock()
+	if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		snapshot.loaded = true
+		snapshot.items, snapshot.err = items, err
+	}
+	close(snapshot.loading)
+	snapshot.loading = nil
+}
+
+// bulkForget makes reads of written items go to the API.
+func (c *Client) bulkForget(itemType string, ids ...string) {
+	if c.bulk == nil {
+		return
+	}
+	snapshot := c.bulk.snapshot(itemType)
+
+	snapshot.mu.Lock()
+	defer snapshot.mu.Unlock()
+	// A snapshot loaded later already sees the write.
+	if !snapshot.loaded && snapshot.loading == nil {
+		return
+	}
+	for _, id := range ids {
+		snapshot.written[id] = true
+	}
+}
+
+// snapshot returns the snapshot of itemType, creating it unloaded.
+func (b *bulkReader) snapshot(itemType string) *bulkSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot, ok := b.snapshots[itemType]
+	if !ok {
+		snapshot = &bulkSnapshot{written: map[string]bool{}}
+		b.snapshots[itemType] = snapshot
+	}
+	return snapshot
+}
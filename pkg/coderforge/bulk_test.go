@@ -0,0 +1,232 @@
+package coderforge
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"terraform-provider-coderforge/internal/fakeserver"
+)
+
+func TestResources_batch(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+	items := NewResources[testItem](client, "test")
+
+	created, err := items.CreateAll(ctx, []testItem{{Name: "a"}, {Name: "b"}, {Name: "c"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(created) != 3 || created[2].Name != "c" {
+		t.Fatalf("unexpected items %+v", created)
+	}
+
+	created[0].Size = 1
+	created[1].Size = 2
+	if _, err := items.UpdateAll(ctx, created[:2]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := items.GetAll(ctx, []string{created[0].ID, created[1].ID, "test-missing"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 2 || got[0].Size != 1 || got[1].Size != 2 {
+		t.Fatalf("unexpected items %+v", got)
+	}
+
+	// A batch with a missing item changes nothing.
+	if err := items.DeleteAll(ctx, []string{created[0].ID, "test-missing"}); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if _, ok := server.Resource(created[0].ID); !ok {
+		t.Fatal("expected the failed batch to leave the item in place")
+	}
+
+	if err := items.DeleteAll(ctx, []string{created[0].ID, created[1].ID, created[2].ID}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := len(server.Resources()); n != 0 {
+		t.Fatalf("expected no items, got %d", n)
+	}
+
+	if writes := len(server.Requests()) - countRequests(server, "GET "); writes != 4 {
+		t.Fatalf("expected 4 write requests, got %d: %v", writes, server.Requests())
+	}
+}
+
+func TestResources_getAllEmpty(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+	items := NewResources[testItem](client, "test")
+
+	if _, err := items.Create(ctx, testItem{Name: "a"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := items.GetAll(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no items, got %+v", got)
+	}
+	if n := countRequests(server, "GET "); n != 0 {
+		t.Fatalf("expected no GET requests, got %d", n)
+	}
+}
+
+func TestResources_otherType(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"direct": nil,
+		"bulk":   {WithBulkReads()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, _ := newTestClient(t, opts...)
+			ctx := context.Background()
+
+			other, err := NewResources[testItem](client, "other").Create(ctx, testItem{Name: "a"})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			items := NewResources[testItem](client, "test")
+			got, err := items.Get(ctx, other.ID)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != nil {
+				t.Fatalf("expected an item of another type not to be found, got %+v", got)
+			}
+			all, err := items.GetAll(ctx, []string{other.ID})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if len(all) != 0 {
+				t.Fatalf("expected no items, got %+v", all)
+			}
+		})
+	}
+}
+
+func TestWithBulkReads(t *testing.T) {
+	client, server := newTestClient(t, WithBulkReads())
+	ctx := context.Background()
+	items := NewResources[testItem](client, "test")
+
+	created, err := items.CreateAll(ctx, []testItem{{Name: "a"}, {Name: "b"}, {Name: "c"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, item := range created {
+		got, err := items.Get(ctx, item.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got == nil || got.Name != item.Name {
+			t.Fatalf("expected %+v, got %+v", item, got)
+		}
+	}
+	if reads := countRequests(server, "GET "); reads != 1 {
+		t.Fatalf("expected a single list request, got %d: %v", reads, server.Requests())
+	}
+
+	// A written item is read from the API again.
+	created[0].Size = 5
+	if _, err := items.Update(ctx, created[0]); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := items.Get(ctx, created[0].ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got.Size != 5 {
+		t.Fatalf("expected size 5, got %d", got.Size)
+	}
+	if reads := countRequests(server, "GET "); reads != 2 {
+		t.Fatalf("expected 2 read requests, got %d: %v", reads, server.Requests())
+	}
+
+	// So is a deleted one.
+	if err := items.Delete(ctx, created[1].ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err = items.Get(ctx, created[1].ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != nil {
+		t.Fatalf("expected deleted item to be gone, got %+v", got)
+	}
+}
+
+func TestWithBulkReads_canceledRead(t *testing.T) {
+	client, server := newTestClient(t, WithBulkReads())
+	items := NewResources[testItem](client, "test")
+
+	created, err := items.Create(context.Background(), testItem{Name: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The read that starts the snapshot gives up, the snapshot does not.
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := items.Get(canceledCtx, created.ID); err == nil {
+		t.Fatal("expected the canceled read to fail")
+	}
+
+	got, err := items.Get(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got == nil || got.Name != "a" {
+		t.Fatalf("expected %+v, got %+v", created, got)
+	}
+	if reads := countRequests(server, "GET "); reads != 1 {
+		t.Fatalf("expected a single list request, got %d: %v", reads, server.Requests())
+	}
+}
+
+func TestWithBulkReads_replayedCreate(t *testing.T) {
+	client, server := newTestClient(t, WithBulkReads())
+	ctx := ContextWithIdempotencyKey(context.Background(), "key-1")
+
+	created, err := client.Functions().Create(ctx, ResourceItem{FunctionName: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, err := client.GetResource(context.Background(), created.ID); err != nil || got == nil {
+		t.Fatalf("expected the function, got %+v, %v", got, err)
+	}
+
+	// The function is deleted outside the client after the snapshot was
+	// loaded, then the create is replayed.
+	server.RemoveResource(created.ID)
+	again, err := client.Functions().Create(ctx, ResourceItem{FunctionName: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !IdempotentReplayed(ctx) || again.ID != created.ID {
+		t.Fatalf("expected the create of %s to be replayed, got %s", created.ID, again.ID)
+	}
+
+	got, err := client.GetResource(context.Background(), again.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != nil {
+		t.Fatalf("expected the deleted function not to be found, got %+v", got)
+	}
+}
+
+func countRequests(server *fakeserver.Server, prefix string) int {
+	var n int
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request, prefix) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,84 @@
+package coderforge
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// WithReadCache makes the client cache the responses of read requests for
+// its lifetime. Concurrent identical reads share one request, and any write
+// through the client empties the cache, so a read never returns data older
+// than the client's last write.
+func WithReadCache() Option {
+	return func(c *Client) {
+		c.cache = &readCache{
+			entries: map[string][]byte{},
+			calls:   map[string]*cacheCall{},
+		}
+	}
+}
+
+// readCache holds response bodies keyed by request URL.
+type readCache struct {
+	mu         sync.Mutex
+	generation uint64
+	entries    map[string][]byte
+	calls      map[string]*cacheCall
+}
+
+// cacheCall is a read in flight that other callers can wait for.
+type cacheCall struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// get returns the cached response for key, or calls fetch once for all
+// concurrent callers. A response is only cached if no write happened while
+// it was fetched.
+func (rc *readCache) get(ctx context.Context, key string, fetch func() ([]byte, error)) ([]byte, error) {
+	rc.mu.Lock()
+	if body, ok := rc.entries[key]; ok {
+		rc.mu.Unlock()
+		return body, nil
+	}
+	if call, ok := rc.calls[key]; ok {
+		rc.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		// The caller that sent the request gave up; send our own.
+		if errors.Is(call.err, context.Canceled) || errors.Is(call.err, context.DeadlineExceeded) {
+			return rc.get(ctx, key, fetch)
+		}
+		return call.body, call.err
+	}
+	call := &cacheCall{done: make(chan struct{})}
+	rc.calls[key] = call
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	call.body, call.err = fetch()
+
+	rc.mu.Lock()
+	delete(rc.calls, key)
+	if call.err == nil && generation == rc.generation {
+		rc.entries[key] = call.body
+	}
+	rc.mu.Unlock()
+	close(call.done)
+
+	return call.body, call.err
+}
+
+// invalidate empties the cache after a write.
+func (rc *readCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	rc.entries = map[string][]byte{}
+}
@@ -0,0 +1,113 @@
+package coderforge
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithReadCache_concurrent(t *testing.T) {
+	release := make(chan struct{})
+	client, arrivals := newRecordingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		okHandler(w, r)
+	}, WithReadCache())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Functions().GetAll(context.Background(), []string{"function-1"})
+			errs <- err
+		}()
+	}
+	// Let every goroutine join the request in flight before answering it.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if _, err := client.Functions().GetAll(context.Background(), []string{"function-1"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := len(arrivals()); n != 1 {
+		t.Fatalf("expected a single request, got %d", n)
+	}
+}
+
+func TestWithReadCache_invalidatedByWrites(t *testing.T) {
+	client, server := newTestClient(t, WithReadCache())
+	ctx := context.Background()
+	items := NewResources[testItem](client, "test")
+
+	created, err := items.Create(ctx, testItem{Name: "a", Size: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := items.GetAll(ctx, []string{created.ID}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if reads := countRequests(server, "GET "); reads != 1 {
+		t.Fatalf("expected a single read request, got %d", reads)
+	}
+
+	created.Size = 2
+	if _, err := items.Update(ctx, *created); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := items.GetAll(ctx, []string{created.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 1 || got[0].Size != 2 {
+		t.Fatalf("expected the updated item, got %+v", got)
+	}
+
+	if err := items.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err = items.GetAll(ctx, []string{created.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected the deleted item to be gone, got %+v", got)
+	}
+}
+
+func TestWithReadCache_errorsNotCached(t *testing.T) {
+	var mu sync.Mutex
+	var calls int
+	client, arrivals := newRecordingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		okHandler(w, r)
+	}, WithReadCache())
+
+	if _, err := client.Functions().GetAll(context.Background(), []string{"function-1"}); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := client.Functions().GetAll(context.Background(), []string{"function-1"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := len(arrivals()); n != 2 {
+		t.Fatalf("expected 2 requests, got %d", n)
+	}
+}
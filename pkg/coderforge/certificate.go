@@ -0,0 +1,58 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WaitForCertificate reads the domain with the given ID every interval until
+// its certificate is issued, and returns the domain as last read. It fails
+// when the certificate fails validation, the domain is deleted or ctx ends.
+//
+// The reads go to the API directly, past the bulk snapshot and the read
+// cache, which would keep answering with the pending certificate.
+func (c *Client) WaitForCertificate(ctx context.Context, domainID string, interval time.Duration) (*Domain, error) {
+	query := url.Values{
+		"resourceId": {domainID},
+		"cloudSpace": {c.CloudSpace},
+	}
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.resourceURL(query), nil)
+		if err != nil {
+			return nil, err
+		}
+		resBody, err := c.doRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		cloudDataRes := cloudDataRaw{}
+		if err := json.Unmarshal(resBody, &cloudDataRes); err != nil {
+			return nil, err
+		}
+		domains, err := decodeItems[Domain](cloudDataRes.ResourceItems)
+		if err != nil {
+			return nil, err
+		}
+		if len(domains) == 0 {
+			return nil, fmt.Errorf("domain %s not found", domainID)
+		}
+
+		domain := &domains[0]
+		switch domain.CertificateStatus {
+		case CertificateIssued:
+			return domain, nil
+		case CertificateFailed:
+			return domain, fmt.Errorf("certificate for %s failed validation", domain.Hostname)
+		}
+
+		select {
+		case <-ctx.Done():
+			return domain, fmt.Errorf("certificate for %s is still %s: %w", domain.Hostname, domain.CertificateStatus, ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package coderforge
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForCertificate(t *testing.T) {
+	client, server := newTestClient(t, WithBulkReads(), WithReadCache())
+	server.PendingCertificateReads = 2
+	ctx := context.Background()
+
+	domain, err := client.Domains().Create(ctx, Domain{Hostname: "api.example.com", CertificateMode: CertificateManaged})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if domain.CertificateStatus != CertificatePending {
+		t.Fatalf("expected a pending certificate, got %q", domain.CertificateStatus)
+	}
+	if len(domain.ValidationRecords) != 1 || domain.ValidationRecords[0].Name != "_coderforge-challenge.api.example.com." {
+		t.Fatalf("unexpected validation records %+v", domain.ValidationRecords)
+	}
+
+	// Prime the bulk snapshot and the read cache with the pending domain.
+	if _, err := client.Domains().Get(ctx, domain.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	domain, err = client.WaitForCertificate(ctx, domain.ID, time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if domain.CertificateStatus != CertificateIssued {
+		t.Fatalf("expected an issued certificate, got %q", domain.CertificateStatus)
+	}
+}
+
+func TestWaitForCertificate_failed(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	domain, err := client.Domains().Create(ctx, Domain{Hostname: "api.example.invalid", CertificateMode: CertificateManaged})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	domain, err = client.WaitForCertificate(ctx, domain.ID, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "failed validation") {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if domain.CertificateStatus != CertificateFailed {
+		t.Fatalf("expected a failed certificate, got %q", domain.CertificateStatus)
+	}
+
+	if _, err := client.WaitForCertificate(ctx, "domain-missing", time.Millisecond); err == nil {
+		t.Fatal("expected an error for a missing domain")
+	}
+}
+
+func TestWaitForCertificate_timeout(t *testing.T) {
+	client, server := newTestClient(t)
+	server.PendingCertificateReads = 1000
+
+	domain, err := client.Domains().Create(context.Background(), Domain{Hostname: "api.example.com", CertificateMode: CertificateManaged})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.WaitForCertificate(ctx, domain.ID, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+}
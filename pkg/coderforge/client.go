@@ -0,0 +1,260 @@
+// Package coderforge is a Go client for the CoderForge.org cloud API. It is
+// used by the Terraform provider and can be imported by other tools that
+// manage CoderForge.org cloud spaces.
+package coderforge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const HostURL string = "https://api.coderforge.org"
+
+// DefaultAPIVersion is the API version used when WithAPIVersion is not given.
+const DefaultAPIVersion = "1.2"
+
+// DefaultRequestTimeout bounds a single HTTP request when
+// WithRequestTimeout is not given.
+const DefaultRequestTimeout = 5 * time.Minute
+
+type Client struct {
+	StackId     string
+	HostURL     string
+	APIVersion  string
+	HTTPClient  *http.Client
+	TokenSource TokenSource
+	CloudSpace  string
+	Locations   []string
+
+	bulk    *bulkReader
+	cache   *readCache
+	limiter *rateLimiter
+}
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithHostURL sets the base URL of the API, for example a staging endpoint
+// or a local fake.
+func WithHostURL(hostURL string) Option {
+	return func(c *Client) {
+		c.HostURL = hostURL
+	}
+}
+
+// WithAPIVersion selects the API version used in request paths.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.APIVersion = version
+	}
+}
+
+// WithTokenSource sets how the client obtains the bearer token sent with
+// every request.
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(c *Client) {
+		c.TokenSource = tokenSource
+	}
+}
+
+// WithStackId sets the stack that created resources belong to.
+func WithStackId(stackId string) Option {
+	return func(c *Client) {
+		c.StackId = stackId
+	}
+}
+
+// WithLocations sets the locations that created resources are deployed to.
+func WithLocations(locations []string) Option {
+	return func(c *Client) {
+		c.Locations = locations
+	}
+}
+
+// WithHTTPClient replaces the HTTP client used for API requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithRequestTimeout bounds every HTTP request. Callers can bound whole
+// operations through the context passed to each method.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		if timeout > 0 {
+			c.HTTPClient = &http.Client{Timeout: timeout}
+		}
+	}
+}
+
+// NewClient returns a client for the given cloud space.
+func NewClient(cloudSpace string, opts ...Option) (*Client, error) {
+	c := Client{
+		HostURL:    HostURL,
+		APIVersion: DefaultAPIVersion,
+		HTTPClient: &http.Client{Timeout: DefaultRequestTimeout},
+		CloudSpace: cloudSpace,
+		limiter:    newRateLimiter(0),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.CloudSpace == "" {
+		return nil, errors.New("cloud space is required")
+	}
+	if c.TokenSource == nil {
+		return nil, errors.New("a token source is required")
+	}
+	return &c, nil
+}
+
+// APIError is returned when the API answers with a status other than
+// 200 OK.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// resourceURL returns the URL of the terraform resource endpoint with the
+// given query parameters.
+func (c *Client) resourceURL(query url.Values) string {
+	return c.apiURL("cloud/terraform/resource", query)
+}
+
+// apiURL returns the URL of an API path with the given query parameters.
+func (c *Client) apiURL(path string, query url.Values) string {
+	u := fmt.Sprintf("%s/api/%s/%s", c.HostURL, c.APIVersion, path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// newCloudData returns a request envelope scoped to the client's cloud
+// space, stack and locations.
+func (c *Client) newCloudData(resourceItems []json.RawMessage) cloudDataRaw {
+	return cloudDataRaw{
+		StackId:       c.StackId,
+		CloudSpace:    c.CloudSpace,
+		Locations:     c.Locations,
+		ResourceItems: resourceItems,
+	}
+}
+
+// do sends a request to the terraform resource endpoint with an optional
+// JSON body and decodes the JSON response into out.
+func (c *Client) do(ctx context.Context, method string, query url.Values, body any, out any) error {
+	return c.doURL(ctx, method, c.resourceURL(query), body, out)
+}
+
+// doURL sends a request with an optional JSON body to u and decodes the
+// JSON response into out.
+func (c *Client) doURL(ctx context.Context, method string, u string, body any, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		rb, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(rb)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return err
+	}
+
+	var resBody []byte
+	switch {
+	case c.cache == nil:
+		resBody, err = c.doRequest(req)
+	case method == http.MethodGet:
+		resBody, err = c.cache.get(ctx, req.URL.String(), func() ([]byte, error) {
+			return c.doRequest(req)
+		})
+	default:
+		resBody, err = c.doRequest(req)
+		c.cache.invalidate()
+	}
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(resBody, out)
+}
+
+// doRequest sends req through the client's rate limiter. Requests answered
+// with 429 Too Many Requests are sent again once the limiter allows it, and
+// creates with an idempotency key are sent again after a timeout or a
+// gateway error.
+func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	token, err := c.TokenSource.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("obtaining API token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-CoderForge.org-Context", "{\"userId\": \"u00001\"}")
+	req.Header.Set("Content-Type", "application/json")
+	if key := idempotencyKeyFromContext(req.Context()); key != "" && req.Method == http.MethodPost {
+		req.Header.Set("Idempotency-Key", key)
+	}
+	if version := ifMatchFromContext(req.Context()); version != "" && (req.Method == http.MethodPut || req.Method == http.MethodDelete) {
+		req.Header.Set("If-Match", `"`+version+`"`)
+	}
+
+	var rateLimited, failed int
+	for {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		res, err := c.HTTPClient.Do(req)
+		var body []byte
+		if err == nil {
+			body, err = io.ReadAll(res.Body)
+			res.Body.Close()
+			c.limiter.observe(res)
+		}
+
+		switch {
+		case retryIdempotent(req, res, err) && failed < maxIdempotentRetries:
+			failed++
+		case err != nil:
+			return nil, err
+		case res.StatusCode == http.StatusTooManyRequests && rateLimited < maxRateLimitRetries:
+			rateLimited++
+		case res.StatusCode != http.StatusOK:
+			return nil, &APIError{StatusCode: res.StatusCode, Body: body}
+		default:
+			recordReplayed(req, res)
+			return body, nil
+		}
+
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
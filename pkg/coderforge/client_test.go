@@ -0,0 +1,139 @@
+package coderforge
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-coderforge/internal/fakeserver"
+)
+
+// testItem is a resource type the client knows nothing about, to exercise
+// the generic CRUD helper.
+type testItem struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	Size int64  `json:"size,omitempty"`
+}
+
+func newTestClient(t *testing.T, opts ...Option) (*Client, *fakeserver.Server) {
+	t.Helper()
+
+	server := fakeserver.New()
+	server.Token = "test-token"
+	t.Cleanup(server.Close)
+
+	opts = append([]Option{
+		WithHostURL(server.URL),
+		WithTokenSource(StaticTokenSource("test-token")),
+		WithStackId("stack-test"),
+		WithLocations([]string{"gbr-1"}),
+	}, opts...)
+	client, err := NewClient("test.coderforge.org", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+func TestResources_CRUD(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+	items := NewResources[testItem](client, "test")
+
+	created, err := items.Create(ctx, testItem{Name: "a", Size: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if created.ID == "" || created.Name != "a" {
+		t.Fatalf("unexpected item %+v", created)
+	}
+	stored, _ := server.Resource(created.ID)
+	if stored["type"] != "test" {
+		t.Fatalf("expected type test, got %v", stored["type"])
+	}
+
+	created.Size = 2
+	if _, err := items.Update(ctx, *created); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	got, err := items.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got == nil || got.Size != 2 {
+		t.Fatalf("expected size 2, got %+v", got)
+	}
+
+	if err := items.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err = items.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != nil {
+		t.Fatalf("expected no item after delete, got %+v", got)
+	}
+
+	if err := items.Delete(ctx, created.ID); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestClient_APIVersion(t *testing.T) {
+	client, server := newTestClient(t, WithAPIVersion("9.9"))
+
+	_, err := client.GetResource(context.Background(), "function-1")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	requests := server.Requests()
+	if len(requests) != 0 {
+		t.Fatalf("expected no requests to the 1.2 API, got %v", requests)
+	}
+}
+
+func TestNewClient_requiresTokenSource(t *testing.T) {
+	if _, err := NewClient("test.coderforge.org"); err == nil {
+		t.Fatal("expected an error without a token source")
+	}
+}
+
+func TestResources_ifMatch(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	created, err := client.Functions().Create(ctx, ResourceItem{FunctionName: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if created.Version == "" {
+		t.Fatal("expected the API to return a version")
+	}
+
+	// A write based on the current version succeeds and changes it.
+	updated, err := client.Functions().Update(ContextWithIfMatch(ctx, created.Version), *created)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if updated.Version == created.Version {
+		t.Fatalf("expected a new version, got %s", updated.Version)
+	}
+
+	// Writes based on an older version are refused.
+	_, err = client.Functions().Update(ContextWithIfMatch(ctx, created.Version), *created)
+	if !IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if err := client.Functions().Delete(ContextWithIfMatch(ctx, created.Version), created.ID); !IsConflict(err) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	if _, ok := server.Resource(created.ID); !ok {
+		t.Fatal("expected the refused delete to leave the function in place")
+	}
+
+	if err := client.Functions().Delete(ContextWithIfMatch(ctx, updated.Version), created.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,93 @@
+package coderforge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// maxIdempotentRetries is how often a create sent with an idempotency key is
+// sent again after a failure that leaves unclear whether the API acted.
+const maxIdempotentRetries = 2
+
+// idempotentReplayedHeader is set by the API on the answer to a create it
+// did not apply because it repeats an earlier one with the same key.
+const idempotentReplayedHeader = "Idempotent-Replayed"
+
+type idempotencyKeyContextKey struct{}
+
+// idempotentCreate is the idempotency key of a create and whether the API
+// answered it with the result of an earlier create.
+type idempotentCreate struct {
+	key      string
+	replayed bool
+}
+
+// ContextWithIdempotencyKey returns a context that makes create requests
+// send key in the Idempotency-Key header. The API answers a repeated create
+// with the same key with the result of the first one, so the client can
+// safely send it again after a timeout or a gateway error.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, &idempotentCreate{key: key})
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	if create, ok := ctx.Value(idempotencyKeyContextKey{}).(*idempotentCreate); ok {
+		return create.key
+	}
+	return ""
+}
+
+// IdempotentReplayed reports whether the API answered the last create sent
+// with a context from ContextWithIdempotencyKey with the result of an earlier
+// create with the same key. The item it returned may have been deleted since.
+func IdempotentReplayed(ctx context.Context) bool {
+	create, ok := ctx.Value(idempotencyKeyContextKey{}).(*idempotentCreate)
+	return ok && create.replayed
+}
+
+// recordReplayed remembers whether the API replayed a create, for
+// IdempotentReplayed.
+func recordReplayed(req *http.Request, res *http.Response) {
+	create, ok := req.Context().Value(idempotencyKeyContextKey{}).(*idempotentCreate)
+	if ok && req.Method == http.MethodPost {
+		create.replayed = res.Header.Get(idempotentReplayedHeader) == "true"
+	}
+}
+
+// IdempotencyKey derives a key from item and the client's cloud space and
+// stack, so creating the same item again yields the same key.
+func (c *Client) IdempotencyKey(item any) (string, error) {
+	rb, err := json.Marshal(struct {
+		CloudSpace string `json:"cloudSpace"`
+		StackId    string `json:"stackId"`
+		Item       any    `json:"item"`
+	}{c.CloudSpace, c.StackId, item})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(rb)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// retryIdempotent reports whether a create with an idempotency key that
+// failed with err or res should be sent again.
+func retryIdempotent(req *http.Request, res *http.Response, err error) bool {
+	if req.Method != http.MethodPost || req.Header.Get("Idempotency-Key") == "" {
+		return false
+	}
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		// The request may or may not have reached the API.
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package coderforge
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	a, err := client.IdempotencyKey(ResourceItem{FunctionName: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	again, _ := client.IdempotencyKey(ResourceItem{FunctionName: "a"})
+	b, _ := client.IdempotencyKey(ResourceItem{FunctionName: "b"})
+	if a != again {
+		t.Fatalf("expected the same item to give the same key, got %s and %s", a, again)
+	}
+	if a == b {
+		t.Fatalf("expected different items to give different keys, got %s", a)
+	}
+}
+
+func TestCreate_idempotentRetry(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := ContextWithIdempotencyKey(context.Background(), "key-1")
+
+	// The API creates the function but the answer is lost.
+	server.FailNextCreates(1)
+	created, err := client.Functions().Create(ctx, ResourceItem{FunctionName: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := len(server.Resources()); n != 1 {
+		t.Fatalf("expected a single function, got %d", n)
+	}
+	if n := countRequests(server, "POST "); n != 2 {
+		t.Fatalf("expected the create to be sent twice, got %d", n)
+	}
+
+	// Sending the create again returns the same function.
+	again, err := client.Functions().Create(ctx, ResourceItem{FunctionName: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if again.ID != created.ID {
+		t.Fatalf("expected %s, got %s", created.ID, again.ID)
+	}
+	if !IdempotentReplayed(ctx) {
+		t.Fatal("expected the repeated create to be reported as replayed")
+	}
+
+	// A different create under the same key is refused.
+	_, err = client.Functions().Create(ctx, ResourceItem{FunctionName: "b"})
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected a 422 API error, got %v", err)
+	}
+}
+
+func TestCreate_notReplayed(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := ContextWithIdempotencyKey(context.Background(), "key-1")
+
+	if _, err := client.Functions().Create(ctx, ResourceItem{FunctionName: "a"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if IdempotentReplayed(ctx) {
+		t.Fatal("expected a first create not to be reported as replayed")
+	}
+}
+
+func TestCreate_withoutIdempotencyKeyNotRetried(t *testing.T) {
+	client, server := newTestClient(t)
+
+	server.FailNextCreates(1)
+	_, err := client.Functions().Create(context.Background(), ResourceItem{FunctionName: "a"})
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected a 504 API error, got %v", err)
+	}
+	if n := countRequests(server, "POST "); n != 1 {
+		t.Fatalf("expected a single create, got %d", n)
+	}
+}
@@ -0,0 +1,36 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Invocation is the result of invoking a function.
+type Invocation struct {
+	// StatusCode is the status the function answered with. A function
+	// that fails still yields an Invocation, so callers can check it.
+	StatusCode int64  `json:"statusCode"`
+	Body       string `json:"body"`
+	DurationMs int64  `json:"durationMs,omitempty"`
+}
+
+type invocationRequest struct {
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// Invoke calls the function with the given ID, passing payload as its JSON
+// input, and waits for the result. An empty payload invokes the function
+// without input.
+func (c *Client) Invoke(ctx context.Context, functionID string, payload json.RawMessage) (*Invocation, error) {
+	query := url.Values{
+		"resourceId": {functionID},
+		"cloudSpace": {c.CloudSpace},
+	}
+	invocation := Invocation{}
+	err := c.doURL(ctx, "POST", c.apiURL("cloud/function/invocation", query), invocationRequest{Payload: payload}, &invocation)
+	if err != nil {
+		return nil, err
+	}
+	return &invocation, nil
+}
@@ -0,0 +1,47 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"terraform-provider-coderforge/internal/fakeserver"
+)
+
+func TestInvoke(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	function, err := client.Functions().Create(ctx, ResourceItem{FunctionName: "hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	invocation, err := client.Invoke(ctx, function.ID, json.RawMessage(`{"name":"smoke"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if invocation.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", invocation.StatusCode)
+	}
+	if invocation.Body != `{"functionName":"hello","payload":{"name":"smoke"}}` {
+		t.Fatalf("unexpected body %s", invocation.Body)
+	}
+
+	// A failing function is reported in the invocation, not as an error.
+	server.SetInvokeHandler(func(fakeserver.Item, json.RawMessage) (int, string) {
+		return http.StatusInternalServerError, "boom"
+	})
+	invocation, err = client.Invoke(ctx, function.ID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if invocation.StatusCode != http.StatusInternalServerError || invocation.Body != "boom" {
+		t.Fatalf("unexpected invocation %+v", invocation)
+	}
+
+	if _, err := client.Invoke(ctx, "function-missing", nil); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ListFilter narrows the items returned by a list. Empty fields do not
+// filter.
+type ListFilter struct {
+	Type       string
+	StackId    string
+	NamePrefix string
+	Location   string
+
+	// PageSize asks the API for pages of this size. Zero uses the API
+	// default.
+	PageSize int
+}
+
+func (f ListFilter) query(cloudSpace string) url.Values {
+	query := url.Values{"cloudSpace": {cloudSpace}}
+	for key, value := range map[string]string{
+		"type":       f.Type,
+		"stackId":    f.StackId,
+		"namePrefix": f.NamePrefix,
+		"location":   f.Location,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if f.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(f.PageSize))
+	}
+	return query
+}
+
+// list returns the undecoded items matching filter, following cursors or
+// page numbers until the API reports no further results.
+func (c *Client) list(ctx context.Context, filter ListFilter) ([]json.RawMessage, error) {
+	query := filter.query(c.CloudSpace)
+
+	var items []json.RawMessage
+	for {
+		cloudDataRes := cloudDataRaw{}
+		if err := c.do(ctx, "GET", query, nil, &cloudDataRes); err != nil {
+			return nil, err
+		}
+		items = append(items, cloudDataRes.ResourceItems...)
+
+		switch {
+		case cloudDataRes.NextCursor != "":
+			if cloudDataRes.NextCursor == query.Get("cursor") {
+				return nil, fmt.Errorf("API returned cursor %q twice", cloudDataRes.NextCursor)
+			}
+			query.Set("cursor", cloudDataRes.NextCursor)
+		case cloudDataRes.Page > 0 && cloudDataRes.Page < cloudDataRes.TotalPages:
+			query.Set("page", strconv.FormatInt(cloudDataRes.Page+1, 10))
+		default:
+			return items, nil
+		}
+	}
+}
+
+// List returns every item of the helper's type that matches filter. The
+// Type of filter is ignored.
+func (r *Resources[T]) List(ctx context.Context, filter ListFilter) ([]T, error) {
+	filter.Type = r.itemType
+	rawItems, err := r.client.list(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return decodeItems[T](rawItems)
+}
+
+// ListResources returns every resource item in the cloud space that matches
+// filter, across all pages.
+func (c *Client) ListResources(ctx context.Context, filter ListFilter) ([]ResourceItem, error) {
+	rawItems, err := c.list(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return decodeItems[ResourceItem](rawItems)
+}
+
+func decodeItems[T any](rawItems []json.RawMessage) ([]T, error) {
+	items := make([]T, 0, len(rawItems))
+	for _, rawItem := range rawItems {
+		var item T
+		if err := json.Unmarshal(rawItem, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
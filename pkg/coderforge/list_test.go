@@ -0,0 +1,77 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestListResources_followsCursors(t *testing.T) {
+	client, server := newTestClient(t)
+	server.PageSize = 2
+	ctx := context.Background()
+
+	for _, name := range []string{"api-a", "api-b", "api-c", "worker-a", "api-d"} {
+		if _, err := client.CreateResource(ctx, ResourceItem{Type: ResourceTypeFunction, FunctionName: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	functions, err := client.Functions().List(ctx, ListFilter{NamePrefix: "api-"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var names []string
+	for _, function := range functions {
+		names = append(names, function.FunctionName)
+	}
+	if fmt.Sprint(names) != "[api-a api-b api-c api-d]" {
+		t.Fatalf("unexpected functions %v", names)
+	}
+	if got := len(server.Requests()); got != 5+2 {
+		t.Fatalf("expected 5 creates and 2 list pages, got %d requests", got)
+	}
+
+	functions, err = client.ListResources(ctx, ListFilter{StackId: "stack-other"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(functions) != 0 {
+		t.Fatalf("expected no functions in another stack, got %d", len(functions))
+	}
+}
+
+func TestListResources_followsPages(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			page = 1
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"resourceItems": []map[string]string{{"id": fmt.Sprintf("function-%d", page), "type": "function"}},
+			"page":          page,
+			"totalPages":    3,
+		})
+	}))
+	t.Cleanup(api.Close)
+
+	client, err := NewClient("test.coderforge.org",
+		WithHostURL(api.URL),
+		WithTokenSource(StaticTokenSource("test-token")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	functions, err := client.ListResources(context.Background(), ListFilter{Type: ResourceTypeFunction})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(functions) != 3 || functions[2].ID != "function-3" {
+		t.Fatalf("unexpected functions %+v", functions)
+	}
+}
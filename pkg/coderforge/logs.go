@@ -0,0 +1,34 @@
+package coderforge
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// LogLine is a line a function logged.
+type LogLine struct {
+	// Timestamp is in RFC 3339 format.
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+}
+
+type logsResponse struct {
+	Lines []LogLine `json:"lines"`
+}
+
+// FunctionLogs returns the last limit lines the function with the given ID
+// logged, oldest first.
+func (c *Client) FunctionLogs(ctx context.Context, functionID string, limit int64) ([]LogLine, error) {
+	query := url.Values{
+		"resourceId": {functionID},
+		"cloudSpace": {c.CloudSpace},
+		"limit":      {strconv.FormatInt(limit, 10)},
+	}
+	logs := logsResponse{}
+	if err := c.doURL(ctx, "GET", c.apiURL("cloud/function/logs", query), nil, &logs); err != nil {
+		return nil, err
+	}
+	return logs.Lines, nil
+}
@@ -0,0 +1,43 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestFunctionLogs(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	function, err := client.Functions().Create(ctx, ResourceItem{FunctionName: "hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	server.Log(function.ID, "debug", "starting")
+	server.Log(function.ID, "error", "connection refused")
+	if _, err := client.Invoke(ctx, function.ID, json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	lines, err := client.FunctionLogs(ctx, function.ID, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %+v", lines)
+	}
+	if lines[0].Level != "error" || lines[0].Message != "connection refused" {
+		t.Fatalf("unexpected first line %+v", lines[0])
+	}
+	if lines[1].Message != "invocation finished with status 200" {
+		t.Fatalf("unexpected last line %+v", lines[1])
+	}
+	if lines[0].Timestamp >= lines[1].Timestamp {
+		t.Fatalf("expected lines oldest first, got %+v", lines)
+	}
+
+	if _, err := client.FunctionLogs(ctx, "function-missing", 10); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
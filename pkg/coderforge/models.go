@@ -0,0 +1,407 @@
+package coderforge
+
+import "encoding/json"
+
+// CloudData is the request and response envelope of the terraform resource
+// endpoint.
+type CloudData struct {
+	StackId       string         `json:"stackId"`
+	CloudSpace    string         `json:"cloudSpace"`
+	Locations     []string       `json:"locations"`
+	ResourceItems []ResourceItem `json:"resourceItems"`
+	DataItems     []DataItem     `json:"dataItems"`
+	NextCursor    string         `json:"nextCursor,omitempty"`
+	Page          int64          `json:"page,omitempty"`
+	TotalPages    int64          `json:"totalPages,omitempty"`
+}
+
+// cloudDataRaw is CloudData with the resource items left undecoded, so that
+// the typed helpers can carry items of any resource type.
+type cloudDataRaw struct {
+	StackId       string            `json:"stackId"`
+	CloudSpace    string            `json:"cloudSpace"`
+	Locations     []string          `json:"locations"`
+	ResourceItems []json.RawMessage `json:"resourceItems"`
+	DataItems     []DataItem        `json:"dataItems"`
+	NextCursor    string            `json:"nextCursor,omitempty"`
+	Page          int64             `json:"page,omitempty"`
+	TotalPages    int64             `json:"totalPages,omitempty"`
+}
+
+// ResourceItem is a function as sent to and returned by the API.
+type ResourceItem struct {
+	ID                    string `json:"id,omitempty"`
+	Type                  string `json:"type"`
+	FunctionName          string `json:"functionName,omitempty"`
+	Code                  Code   `json:"code,omitempty"`
+	Timeout               int64  `json:"timeout,omitempty"`
+	Protocol              string `json:"protocol,omitempty"`
+	Domain                string `json:"domain,omitempty"`
+	Port                  int64  `json:"port,omitempty"`
+	Active                bool   `json:"active,omitempty"`
+	LoadBalancePercentage int64  `json:"loadBalancePercentage,omitempty"`
+	MaxRamSize            string `json:"maxRamSize"`
+
+	// Publish makes the write publish an immutable version of the
+	// function, numbered from 1. PublishedVersion is the latest one.
+	Publish          bool  `json:"publish,omitempty"`
+	PublishedVersion int64 `json:"publishedVersion,omitempty"`
+
+	// Secrets maps environment variable names to the names of the secrets
+	// the function receives in them.
+	Secrets map[string]string `json:"secrets,omitempty"`
+
+	// Network attaches the function to a private network.
+	Network *FunctionNetwork `json:"network,omitempty"`
+
+	// Mounts are the volumes mounted into the function.
+	Mounts []Mount `json:"mounts,omitempty"`
+
+	// Version is set by the API and changes on every write. Send it back
+	// with ContextWithIfMatch to make a write conditional.
+	Version string `json:"version,omitempty"`
+}
+
+// FunctionAlias routes a name to a published version of a function, and
+// optionally a weighted share of requests to a second version.
+type FunctionAlias struct {
+	ID                      string `json:"id,omitempty"`
+	Name                    string `json:"name"`
+	FunctionId              string `json:"functionId"`
+	FunctionVersion         int64  `json:"functionVersion"`
+	AdditionalVersion       int64  `json:"additionalVersion,omitempty"`
+	AdditionalVersionWeight int64  `json:"additionalVersionWeight,omitempty"`
+}
+
+// Schedule invokes a function on a cron expression or at a fixed rate.
+type Schedule struct {
+	ID         string `json:"id,omitempty"`
+	Name       string `json:"name"`
+	Cron       string `json:"cron,omitempty"`
+	Rate       string `json:"rate,omitempty"`
+	Timezone   string `json:"timezone,omitempty"`
+	FunctionId string `json:"functionId"`
+	// Payload is the JSON document the function is invoked with.
+	Payload string `json:"payload,omitempty"`
+	Enabled bool   `json:"enabled"`
+}
+
+// Event source types.
+const (
+	EventSourceQueue   = "queue"
+	EventSourceTopic   = "topic"
+	EventSourceWebhook = "webhook"
+)
+
+// EventSource invokes a function with the messages of a queue or topic, or
+// with the requests sent to a signed webhook.
+type EventSource struct {
+	ID              string       `json:"id,omitempty"`
+	Name            string       `json:"name"`
+	SourceType      string       `json:"sourceType"`
+	FunctionId      string       `json:"functionId"`
+	Queue           string       `json:"queue,omitempty"`
+	Topic           string       `json:"topic,omitempty"`
+	BatchSize       int64        `json:"batchSize,omitempty"`
+	RetryPolicy     *RetryPolicy `json:"retryPolicy,omitempty"`
+	DeadLetterQueue string       `json:"deadLetterQueue,omitempty"`
+	Enabled         bool         `json:"enabled"`
+
+	// WebhookURL and WebhookSecret are set by the API for webhooks.
+	// Requests must be signed with the secret.
+	WebhookURL    string `json:"webhookUrl,omitempty"`
+	WebhookSecret string `json:"webhookSecret,omitempty"`
+}
+
+// RetryPolicy controls how often a failed invocation is retried before the
+// event goes to the dead-letter queue.
+type RetryPolicy struct {
+	MaxAttempts int64  `json:"maxAttempts"`
+	Backoff     string `json:"backoff,omitempty"`
+}
+
+// Certificate modes of a domain.
+const (
+	CertificateManaged  = "managed"
+	CertificateUploaded = "uploaded"
+)
+
+// Certificate statuses of a domain.
+const (
+	CertificatePending = "pending"
+	CertificateIssued  = "issued"
+	CertificateFailed  = "failed"
+)
+
+// Domain serves the public endpoints of the cloud space on a hostname, with
+// an uploaded certificate or one the API requests and renews.
+type Domain struct {
+	ID              string `json:"id,omitempty"`
+	Hostname        string `json:"hostname"`
+	CertificateMode string `json:"certificateMode"`
+
+	// CertificatePem and PrivateKeyPem are the uploaded certificate chain
+	// and its key. The API never returns the key.
+	CertificatePem string `json:"certificatePem,omitempty"`
+	PrivateKeyPem  string `json:"privateKeyPem,omitempty"`
+
+	// CertificateStatus and ValidationRecords are set by the API. A
+	// managed certificate stays pending until the validation records are
+	// published in DNS.
+	CertificateStatus string      `json:"certificateStatus,omitempty"`
+	ValidationRecords []DNSRecord `json:"validationRecords,omitempty"`
+}
+
+// DNSRecord is a record to publish in the DNS zone of a domain.
+type DNSRecord struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Log drain protocols.
+const (
+	LogDrainSyslog = "syslog"
+	LogDrainHTTP   = "http"
+	LogDrainOTLP   = "otlp"
+)
+
+// LogDrain forwards the logs of the cloud space to an external endpoint.
+type LogDrain struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Protocol string `json:"protocol"`
+	Endpoint string `json:"endpoint"`
+	// Headers are sent with every HTTP and OTLP request, for example to
+	// authenticate. The API never returns them.
+	Headers map[string]string `json:"headers,omitempty"`
+	Filter  *LogFilter        `json:"filter,omitempty"`
+	Enabled bool              `json:"enabled"`
+}
+
+// LogFilter selects the log lines a drain forwards. Empty fields match
+// every line.
+type LogFilter struct {
+	FunctionIds []string `json:"functionIds,omitempty"`
+	MinLevel    string   `json:"minLevel,omitempty"`
+	// Pattern is an RE2 regular expression the message must match.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// Notification channel types.
+const (
+	NotificationEmail   = "email"
+	NotificationWebhook = "webhook"
+	NotificationSlack   = "slack"
+)
+
+// NotificationChannel is where alerts are sent: email addresses, or a
+// webhook that receives JSON, optionally in the Slack message format.
+type NotificationChannel struct {
+	ID             string   `json:"id,omitempty"`
+	Name           string   `json:"name"`
+	ChannelType    string   `json:"channelType"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+	URL            string   `json:"url,omitempty"`
+}
+
+// Alert metrics of a function.
+const (
+	MetricErrors      = "errors"
+	MetricThrottles   = "throttles"
+	MetricInvocations = "invocations"
+	MetricDurationP50 = "duration_p50"
+	MetricDurationP95 = "duration_p95"
+	MetricDurationP99 = "duration_p99"
+)
+
+// Alert notifies channels when a metric of a function crosses a threshold
+// over a window. Durations are measured in milliseconds.
+type Alert struct {
+	ID         string  `json:"id,omitempty"`
+	Name       string  `json:"name"`
+	FunctionId string  `json:"functionId"`
+	Metric     string  `json:"metric"`
+	Comparison string  `json:"comparison"`
+	Threshold  float64 `json:"threshold"`
+	// Window is a duration such as "5m".
+	Window     string   `json:"window"`
+	ChannelIds []string `json:"channelIds"`
+	Enabled    bool     `json:"enabled"`
+}
+
+// Secret is a value functions receive in an environment variable. The API
+// never returns the value, only its hex encoded SHA-256 hash.
+type Secret struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	ValueSha256 string `json:"valueSha256,omitempty"`
+	// Rotation counts the times the value was rotated.
+	Rotation int64 `json:"rotation,omitempty"`
+}
+
+// Access policy actions.
+const (
+	ActionInvoke   = "invoke"
+	ActionRead     = "read"
+	ActionReadLogs = "read_logs"
+)
+
+// AccessPolicy allows principals to act on functions and on the endpoints
+// of domains. Principals are written "<kind>:<id>", where kind is api_key,
+// user or group.
+type AccessPolicy struct {
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Principals  []string `json:"principals"`
+	FunctionIds []string `json:"functionIds,omitempty"`
+	DomainIds   []string `json:"domainIds,omitempty"`
+	Actions     []string `json:"actions"`
+}
+
+// APIKey authenticates calls to the endpoints of the domains it is attached
+// to.
+type APIKey struct {
+	ID        string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	DomainIds []string `json:"domainIds,omitempty"`
+	// ExpiresAt is an RFC 3339 timestamp, or empty for keys that do not
+	// expire.
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	Rotation  int64  `json:"rotation,omitempty"`
+
+	// Key is only returned by the create request. KeyPrefix identifies
+	// the key afterwards.
+	Key       string `json:"key,omitempty"`
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+}
+
+// Roles of a role binding.
+const (
+	RoleViewer   = "viewer"
+	RoleDeployer = "deployer"
+	RoleAdmin    = "admin"
+)
+
+// RoleBinding grants a principal a role on the cloud space, or on one stack
+// of it. Principals are written "user:<id>" or "group:<name>".
+type RoleBinding struct {
+	ID        string `json:"id,omitempty"`
+	Principal string `json:"principal"`
+	Role      string `json:"role"`
+	// StackId limits the binding to a stack. Bindings without one apply
+	// to the whole cloud space.
+	StackId string `json:"stackId,omitempty"`
+}
+
+// Network is a private network functions can be attached to, to reach
+// services that are not exposed to the internet. It spans locations, with
+// subnets in each.
+type Network struct {
+	ID        string   `json:"id,omitempty"`
+	Name      string   `json:"name"`
+	CidrBlock string   `json:"cidrBlock"`
+	Locations []string `json:"locations"`
+	Subnets   []Subnet `json:"subnets,omitempty"`
+}
+
+// Subnet is a range of the addresses of a network in one location.
+type Subnet struct {
+	Name      string `json:"name"`
+	CidrBlock string `json:"cidrBlock"`
+	Location  string `json:"location"`
+}
+
+// Egress modes of a function attached to a network.
+const (
+	EgressAll         = "all"
+	EgressPrivateOnly = "private_only"
+	EgressNone        = "none"
+)
+
+// FunctionNetwork attaches a function to subnets of a network. Egress
+// controls which outbound connections the function may open: to anywhere,
+// only to private addresses, or none at all.
+type FunctionNetwork struct {
+	NetworkId string `json:"networkId"`
+	// Subnets are subnet names. Without any, the function is attached to
+	// every subnet in its locations.
+	Subnets []string `json:"subnets,omitempty"`
+	Egress  string   `json:"egress,omitempty"`
+}
+
+// Volume access modes.
+const (
+	// AccessReadWriteOnce volumes are mounted read-write by one function.
+	AccessReadWriteOnce = "read_write_once"
+	// AccessReadWriteMany volumes are mounted read-write by any function.
+	AccessReadWriteMany = "read_write_many"
+	// AccessReadOnlyMany volumes are mounted read-only by any function.
+	AccessReadOnlyMany = "read_only_many"
+)
+
+// Volume is persistent storage functions can mount, replicated to its
+// locations.
+type Volume struct {
+	ID         string   `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	SizeGb     int64    `json:"sizeGb"`
+	Locations  []string `json:"locations"`
+	AccessMode string   `json:"accessMode"`
+}
+
+// Mount mounts a volume into a function at an absolute path.
+type Mount struct {
+	VolumeId  string `json:"volumeId"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly,omitempty"`
+}
+
+// PackageTypeContainerImage is the package type of functions deployed from
+// a container image.
+const PackageTypeContainerImage = "container_image"
+
+type Code struct {
+	PackageType string `json:"packageType"`
+	ImageUri    string `json:"imageUri,omitempty"`
+
+	// Command, Args, WorkingDir, Port and HealthCheck override how a
+	// container image starts and is probed. They default to the image
+	// configuration.
+	Command     []string     `json:"command,omitempty"`
+	Args        []string     `json:"args,omitempty"`
+	WorkingDir  string       `json:"workingDir,omitempty"`
+	Port        int64        `json:"port,omitempty"`
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+}
+
+// Health check types.
+const (
+	HealthCheckHTTP = "http"
+	HealthCheckTCP  = "tcp"
+)
+
+// HealthCheck probes a container to decide when it is ready. An HTTP check
+// passes on a 2xx or 3xx answer to a GET of Path, a TCP check when the port
+// accepts connections.
+type HealthCheck struct {
+	Type string `json:"type"`
+	Path string `json:"path,omitempty"`
+	// Port defaults to the port of the container.
+	Port               int64 `json:"port,omitempty"`
+	IntervalSeconds    int64 `json:"intervalSeconds"`
+	TimeoutSeconds     int64 `json:"timeoutSeconds"`
+	HealthyThreshold   int64 `json:"healthyThreshold"`
+	UnhealthyThreshold int64 `json:"unhealthyThreshold"`
+}
+
+type DataItem struct {
+	ID    string `json:"id,omitempty"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type LogoutStruct struct {
+	IdTokenHint string `json:"id_token_hint"`
+}
@@ -0,0 +1,29 @@
+package coderforge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+type ifMatchContextKey struct{}
+
+// ContextWithIfMatch returns a context that makes updates and deletes send
+// version in the If-Match header. The API then refuses the write with 412
+// Precondition Failed if the item was changed since version was read.
+func ContextWithIfMatch(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, ifMatchContextKey{}, version)
+}
+
+func ifMatchFromContext(ctx context.Context) string {
+	version, _ := ctx.Value(ifMatchContextKey{}).(string)
+	return version
+}
+
+// IsConflict reports whether err is an API error with status 409 or 412,
+// meaning the item was changed by someone else.
+func IsConflict(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusPreconditionFailed)
+}
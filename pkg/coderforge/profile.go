@@ -0,0 +1,102 @@
+package coderforge
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultCredentialsFile is the shared credentials file of the CoderForge.org
+// tools.
+const DefaultCredentialsFile = "~/.coderforge/credentials"
+
+// DefaultProfile is the profile used when none is selected.
+const DefaultProfile = "default"
+
+// Profile holds the settings of one named profile in the shared credentials
+// file, for example:
+//
+//	[prod]
+//	endpoint    = https://api.coderforge.org
+//	token       = ...
+//	cloud_space = ledger.coderforge.org
+//	stack_id    = stack-ledger-prod
+//	locations   = gbr-1, gbr-2
+type Profile struct {
+	Endpoint   string
+	Token      string
+	CloudSpace string
+	StackId    string
+	Locations  []string
+}
+
+// ErrProfileNotFound is returned by LoadProfile when the credentials file has
+// no section for the requested profile.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// LoadProfile reads the named profile from the INI-style credentials file at
+// path. Blank lines and lines starting with "#" or ";" are ignored.
+func LoadProfile(path string, name string) (*Profile, error) {
+	f, err := os.Open(expandHome(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var profile *Profile
+	section := ""
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == name {
+				profile = &Profile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNo)
+		}
+		if section != name {
+			continue
+		}
+
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "endpoint":
+			profile.Endpoint = value
+		case "token":
+			profile.Token = value
+		case "cloud_space":
+			profile.CloudSpace = value
+		case "stack_id":
+			profile.StackId = value
+		case "locations":
+			profile.Locations = nil
+			for _, location := range strings.Split(value, ",") {
+				if location = strings.TrimSpace(location); location != "" {
+					profile.Locations = append(profile.Locations, location)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown key %q in profile %q", path, lineNo, key, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if profile == nil {
+		return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, name, path)
+	}
+	return profile, nil
+}
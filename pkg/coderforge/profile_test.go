@@ -0,0 +1,68 @@
+package coderforge
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testCredentialsFile = `# CoderForge.org credentials
+[default]
+token = dev-token
+cloud_space = ledger.dev.coderforge.org
+
+[prod]
+endpoint    = https://api.prod.coderforge.org
+token       = prod-token
+cloud_space = ledger.coderforge.org
+stack_id    = stack-ledger-prod
+locations   = gbr-1, gbr-2
+`
+
+func TestLoadProfile(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(credentialsFile, []byte(testCredentialsFile), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	profile, err := LoadProfile(credentialsFile, "prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := &Profile{
+		Endpoint:   "https://api.prod.coderforge.org",
+		Token:      "prod-token",
+		CloudSpace: "ledger.coderforge.org",
+		StackId:    "stack-ledger-prod",
+		Locations:  []string{"gbr-1", "gbr-2"},
+	}
+	if !reflect.DeepEqual(profile, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, profile)
+	}
+
+	profile, err = LoadProfile(credentialsFile, DefaultProfile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if profile.Token != "dev-token" || profile.StackId != "" {
+		t.Fatalf("unexpected default profile %+v", profile)
+	}
+
+	_, err = LoadProfile(credentialsFile, "staging")
+	if !errors.Is(err, ErrProfileNotFound) {
+		t.Fatalf("expected ErrProfileNotFound, got %v", err)
+	}
+}
+
+func TestLoadProfile_unknownKey(t *testing.T) {
+	credentialsFile := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(credentialsFile, []byte("[default]\npassword = x\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadProfile(credentialsFile, DefaultProfile); err == nil {
+		t.Fatal("expected an error for an unknown key")
+	}
+}
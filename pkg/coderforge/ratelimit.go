@@ -0,0 +1,149 @@
+package coderforge
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRateLimitRetries is how often a request answered with 429 Too Many
+// Requests is sent again before the error is returned.
+const maxRateLimitRetries = 3
+
+// WithRateLimit limits the client to requestsPerSecond API requests, with
+// bursts of up to one second's worth of requests. The limit is shared by
+// every goroutine using the client. Zero or less disables the limit; the
+// client then still backs off when the API reports it is rate limited.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(requestsPerSecond)
+	}
+}
+
+// rateLimiter is a token bucket that can be paused until a time given by the
+// API, for example in a Retry-After header.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64) *rateLimiter {
+	l := &rateLimiter{}
+	if requestsPerSecond > 0 {
+		l.rate = requestsPerSecond
+		l.burst = math.Max(1, math.Ceil(requestsPerSecond))
+		l.tokens = l.burst
+	}
+	return l
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before
+// using it. Tokens may go negative, so waiting callers are served in order.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var delay time.Duration
+	if l.rate > 0 {
+		if !l.last.IsZero() {
+			l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		}
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+	if pause := l.pausedUntil.Sub(now); pause > delay {
+		delay = pause
+	}
+	return delay
+}
+
+// cancel returns the token of a reservation that was not used.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+1)
+	}
+}
+
+// pause holds every request until the given time.
+func (l *rateLimiter) pause(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// observe adapts the limiter to the rate limit headers of a response. A 429
+// or 503 response pauses all requests for its Retry-After, and an
+// X-RateLimit-Remaining of 0 pauses them for the X-RateLimit-Reset seconds.
+func (l *rateLimiter) observe(res *http.Response) {
+	now := time.Now()
+
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable {
+		if delay, ok := parseRetryAfter(res.Header.Get("Retry-After"), now); ok {
+			l.pause(now.Add(delay))
+			return
+		}
+		if res.StatusCode == http.StatusTooManyRequests {
+			l.pause(now.Add(time.Second))
+			return
+		}
+	}
+
+	if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		if seconds, err := strconv.ParseFloat(res.Header.Get("X-RateLimit-Reset"), 64); err == nil && seconds > 0 {
+			l.pause(now.Add(time.Duration(seconds * float64(time.Second))))
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now), true
+	}
+	return 0, false
+}
@@ -0,0 +1,164 @@
+package coderforge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newRecordingTestClient returns a client for a server that answers every
+// request with handler and records when requests arrive.
+func newRecordingTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) (*Client, func() []time.Time) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var arrivals []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	opts = append([]Option{
+		WithHostURL(server.URL),
+		WithTokenSource(StaticTokenSource("test-token")),
+	}, opts...)
+	client, err := NewClient("test.coderforge.org", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, func() []time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]time.Time(nil), arrivals...)
+	}
+}
+
+func okHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte(`{"resourceItems": []}`))
+}
+
+func TestWithRateLimit_concurrent(t *testing.T) {
+	const (
+		rate     = 40
+		requests = 80
+	)
+	client, arrivals := newRecordingTestClient(t, okHandler, WithRateLimit(rate))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Functions().GetAll(context.Background(), []string{"function-1"})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// A token bucket lets through at most burst + rate*d requests in any
+	// window of length d. Allow one request of scheduling slack.
+	times := arrivals()
+	if len(times) != requests {
+		t.Fatalf("expected %d requests, got %d", requests, len(times))
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	for i := range times {
+		for j := i; j < len(times); j++ {
+			allowed := rate + rate*times[j].Sub(times[i]).Seconds() + 1
+			if n := float64(j - i + 1); n > allowed {
+				t.Fatalf("%v requests within %s, limit allows %v", n, times[j].Sub(times[i]), allowed)
+			}
+		}
+	}
+}
+
+func TestWithRateLimit_cancel(t *testing.T) {
+	client, _ := newRecordingTestClient(t, okHandler, WithRateLimit(1))
+
+	if _, err := client.Functions().GetAll(context.Background(), []string{"function-1"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Functions().GetAll(ctx, []string{"function-1"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestRateLimit_retryAfter(t *testing.T) {
+	var mu sync.Mutex
+	var calls int
+	client, arrivals := newRecordingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+		if first {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		okHandler(w, r)
+	})
+
+	_, err := client.Functions().Create(context.Background(), ResourceItem{FunctionName: "a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	times := arrivals()
+	if len(times) != 2 {
+		t.Fatalf("expected the request to be retried once, got %d requests", len(times))
+	}
+	if wait := times[1].Sub(times[0]); wait < 900*time.Millisecond {
+		t.Fatalf("expected the retry to wait for Retry-After, waited %s", wait)
+	}
+}
+
+func TestRateLimit_retriesExhausted(t *testing.T) {
+	client, arrivals := newRecordingTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := client.Functions().GetAll(context.Background(), []string{"function-1"})
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected a 429 API error, got %v", err)
+	}
+	if n := len(arrivals()); n != maxRateLimitRetries+1 {
+		t.Fatalf("expected %d requests, got %d", maxRateLimitRetries+1, n)
+	}
+}
+
+func TestRateLimit_remainingExhausted(t *testing.T) {
+	client, arrivals := newRecordingTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "0.5")
+		okHandler(w, r)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Functions().GetAll(context.Background(), []string{"function-1"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	times := arrivals()
+	if wait := times[1].Sub(times[0]); wait < 400*time.Millisecond {
+		t.Fatalf("expected the second request to wait for the reset, waited %s", wait)
+	}
+}
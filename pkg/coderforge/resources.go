@@ -0,0 +1,279 @@
+package coderforge
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Resource types, as sent in the "type" field of resource items.
+const (
+	ResourceTypeFunction            = "function"
+	ResourceTypeFunctionAlias       = "function_alias"
+	ResourceTypeSchedule            = "schedule"
+	ResourceTypeEventSource         = "event_source"
+	ResourceTypeDomain              = "domain"
+	ResourceTypeLogDrain            = "log_drain"
+	ResourceTypeNotificationChannel = "notification_channel"
+	ResourceTypeAlert               = "alert"
+	ResourceTypeSecret              = "secret"
+	ResourceTypeAccessPolicy        = "access_policy"
+	ResourceTypeAPIKey              = "api_key"
+	ResourceTypeRoleBinding         = "role_binding"
+	ResourceTypeNetwork             = "network"
+	ResourceTypeVolume              = "volume"
+)
+
+// Resources is a typed CRUD helper for the items of one ResourceItem.Type.
+// T is the Go type an item is encoded to and decoded from. The helper sets
+// the "type" field of every item it sends, so T does not need to carry it.
+type Resources[T any] struct {
+	client   *Client
+	itemType string
+}
+
+// NewResources returns the CRUD helper for items of the given type.
+func NewResources[T any](c *Client, itemType string) *Resources[T] {
+	return &Resources[T]{client: c, itemType: itemType}
+}
+
+// Get returns the item with the given ID, or nil if it does not exist or is
+// of another type.
+func (r *Resources[T]) Get(ctx context.Context, id string) (*T, error) {
+	if rawItem, ok := r.client.bulkGet(ctx, r.itemType, id); ok {
+		item := new(T)
+		if err := json.Unmarshal(rawItem, item); err != nil {
+			return nil, err
+		}
+		return item, nil
+	}
+
+	items, err := r.GetAll(ctx, []string{id})
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// GetAll returns the items with the given IDs in a single request. IDs that
+// do not exist or belong to an item of another type are left out of the
+// result. An empty ids returns no items without a request, as the API would
+// list the whole cloud space.
+func (r *Resources[T]) GetAll(ctx context.Context, ids []string) ([]T, error) {
+	if len(ids) == 0 {
+		return []T{}, nil
+	}
+	query := url.Values{
+		"resourceId": ids,
+		"cloudSpace": {r.client.CloudSpace},
+	}
+	cloudDataRes := cloudDataRaw{}
+	if err := r.client.do(ctx, "GET", query, nil, &cloudDataRes); err != nil {
+		return nil, err
+	}
+	return decodeItems[T](ofType(cloudDataRes.ResourceItems, r.itemType))
+}
+
+// Create creates item and returns it as stored by the API, including its ID.
+func (r *Resources[T]) Create(ctx context.Context, item T) (*T, error) {
+	return firstOf(r.CreateAll(ctx, []T{item}))
+}
+
+// CreateAll creates all items in a single request, which the API applies
+// atomically. The result is in the order of items.
+func (r *Resources[T]) CreateAll(ctx context.Context, items []T) ([]T, error) {
+	return r.send(ctx, "POST", items)
+}
+
+// Update replaces the item with the ID set in item.
+func (r *Resources[T]) Update(ctx context.Context, item T) (*T, error) {
+	return firstOf(r.UpdateAll(ctx, []T{item}))
+}
+
+// UpdateAll replaces all items in a single request, which the API applies
+// atomically. The result is in the order of items.
+func (r *Resources[T]) UpdateAll(ctx context.Context, items []T) ([]T, error) {
+	return r.send(ctx, "PUT", items)
+}
+
+// Delete deletes the item with the given ID.
+func (r *Resources[T]) Delete(ctx context.Context, id string) error {
+	return r.DeleteAll(ctx, []string{id})
+}
+
+// DeleteAll deletes the items with the given IDs in a single request, which
+// the API applies atomically.
+func (r *Resources[T]) DeleteAll(ctx context.Context, ids []string) error {
+	defer r.client.bulkForget(r.itemType, ids...)
+
+	query := url.Values{"resourceId": ids}
+	return r.client.do(ctx, "DELETE", query, r.client.newCloudData(nil), &cloudDataRaw{})
+}
+
+func (r *Resources[T]) send(ctx context.Context, method string, items []T) ([]T, error) {
+	rawItems := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		rawItem, err := r.encode(item)
+		if err != nil {
+			return nil, err
+		}
+		rawItems = append(rawItems, rawItem)
+	}
+
+	defer r.client.bulkForget(r.itemType, itemIDs(rawItems)...)
+
+	cloudDataRes := cloudDataRaw{}
+	err := r.client.do(ctx, method, nil, r.client.newCloudData(rawItems), &cloudDataRes)
+	if err != nil {
+		return nil, err
+	}
+	// Created items only get their IDs here, and a replayed create names an
+	// item the snapshot may hold from before it was deleted.
+	r.client.bulkForget(r.itemType, itemIDs(cloudDataRes.ResourceItems)...)
+	return decodeItems[T](cloudDataRes.ResourceItems)
+}
+
+// encode marshals item and sets its "type" field.
+func (r *Resources[T]) encode(item T) (json.RawMessage, error) {
+	rb, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(rb, &fields); err != nil {
+		return nil, err
+	}
+	fields["type"], err = json.Marshal(r.itemType)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// firstOf returns the first item of a batch result, or nil if it is empty.
+func firstOf[T any](items []T, err error) (*T, error) {
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	return &items[0], nil
+}
+
+// itemID returns the "id" field of an undecoded item.
+func itemID(rawItem json.RawMessage) string {
+	var item struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(rawItem, &item)
+	return item.ID
+}
+
+// ofType returns the undecoded items of the given type.
+func ofType(rawItems []json.RawMessage, itemType string) []json.RawMessage {
+	typed := make([]json.RawMessage, 0, len(rawItems))
+	for _, rawItem := range rawItems {
+		var item struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(rawItem, &item); err == nil && item.Type == itemType {
+			typed = append(typed, rawItem)
+		}
+	}
+	return typed
+}
+
+// itemIDs returns the IDs of the undecoded items that have one.
+func itemIDs(rawItems []json.RawMessage) []string {
+	ids := make([]string, 0, len(rawItems))
+	for _, rawItem := range rawItems {
+		if id := itemID(rawItem); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Functions returns the CRUD helper for functions.
+func (c *Client) Functions() *Resources[ResourceItem] {
+	return NewResources[ResourceItem](c, ResourceTypeFunction)
+}
+
+// FunctionAliases returns the CRUD helper for function aliases.
+func (c *Client) FunctionAliases() *Resources[FunctionAlias] {
+	return NewResources[FunctionAlias](c, ResourceTypeFunctionAlias)
+}
+
+// Schedules returns the CRUD helper for schedules.
+func (c *Client) Schedules() *Resources[Schedule] {
+	return NewResources[Schedule](c, ResourceTypeSchedule)
+}
+
+// EventSources returns the CRUD helper for event sources.
+func (c *Client) EventSources() *Resources[EventSource] {
+	return NewResources[EventSource](c, ResourceTypeEventSource)
+}
+
+// Domains returns the CRUD helper for domains.
+func (c *Client) Domains() *Resources[Domain] {
+	return NewResources[Domain](c, ResourceTypeDomain)
+}
+
+// LogDrains returns the CRUD helper for log drains.
+func (c *Client) LogDrains() *Resources[LogDrain] {
+	return NewResources[LogDrain](c, ResourceTypeLogDrain)
+}
+
+// NotificationChannels returns the CRUD helper for notification channels.
+func (c *Client) NotificationChannels() *Resources[NotificationChannel] {
+	return NewResources[NotificationChannel](c, ResourceTypeNotificationChannel)
+}
+
+// Alerts returns the CRUD helper for alerts.
+func (c *Client) Alerts() *Resources[Alert] {
+	return NewResources[Alert](c, ResourceTypeAlert)
+}
+
+// Secrets returns the CRUD helper for secrets.
+func (c *Client) Secrets() *Resources[Secret] {
+	return NewResources[Secret](c, ResourceTypeSecret)
+}
+
+// AccessPolicies returns the CRUD helper for access policies.
+func (c *Client) AccessPolicies() *Resources[AccessPolicy] {
+	return NewResources[AccessPolicy](c, ResourceTypeAccessPolicy)
+}
+
+// APIKeys returns the CRUD helper for API keys.
+func (c *Client) APIKeys() *Resources[APIKey] {
+	return NewResources[APIKey](c, ResourceTypeAPIKey)
+}
+
+// RoleBindings returns the CRUD helper for role bindings.
+func (c *Client) RoleBindings() *Resources[RoleBinding] {
+	return NewResources[RoleBinding](c, ResourceTypeRoleBinding)
+}
+
+// Networks returns the CRUD helper for networks.
+func (c *Client) Networks() *Resources[Network] {
+	return NewResources[Network](c, ResourceTypeNetwork)
+}
+
+// Volumes returns the CRUD helper for volumes.
+func (c *Client) Volumes() *Resources[Volume] {
+	return NewResources[Volume](c, ResourceTypeVolume)
+}
+
+func (c *Client) GetResource(ctx context.Context, resourceID string) (*ResourceItem, error) {
+	return c.Functions().Get(ctx, resourceID)
+}
+
+func (c *Client) CreateResource(ctx context.Context, resourceItem ResourceItem) (*ResourceItem, error) {
+	return NewResources[ResourceItem](c, resourceItem.Type).Create(ctx, resourceItem)
+}
+
+func (c *Client) UpdateResource(ctx context.Context, resourceItem ResourceItem) (*ResourceItem, error) {
+	return NewResources[ResourceItem](c, resourceItem.Type).Update(ctx, resourceItem)
+}
+
+func (c *Client) DeleteResource(ctx context.Context, resourceID string) error {
+	return c.Functions().Delete(ctx, resourceID)
+}
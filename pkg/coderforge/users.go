@@ -0,0 +1,28 @@
+package coderforge
+
+import (
+	"context"
+	"net/url"
+)
+
+// User is a member of the organization that can be granted roles.
+type User struct {
+	ID     string   `json:"id"`
+	Email  string   `json:"email"`
+	Name   string   `json:"name,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// User returns the user with the given email address. An empty email
+// returns the user the client is authenticated as.
+func (c *Client) User(ctx context.Context, email string) (*User, error) {
+	query := url.Values{}
+	if email != "" {
+		query.Set("email", email)
+	}
+	user := User{}
+	if err := c.doURL(ctx, "GET", c.apiURL("cloud/user", query), nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
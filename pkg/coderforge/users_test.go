@@ -0,0 +1,34 @@
+package coderforge
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUser(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	ownerID := server.AddUser("owner@example.com", "Owner")
+	janeID := server.AddUser("jane@example.com", "Jane Doe", "developers")
+
+	user, err := client.User(ctx, "jane@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user.ID != janeID || user.Name != "Jane Doe" || len(user.Groups) != 1 || user.Groups[0] != "developers" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+
+	user, err = client.User(ctx, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user.ID != ownerID {
+		t.Fatalf("expected the authenticated user, got %+v", user)
+	}
+
+	if _, err := client.User(ctx, "nobody@example.com"); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}